@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"meteo/internal/services/cache"
+)
+
+const cacheUsage = "Usage: meteo cache clear\n"
+
+func runCache(args []string, stdout, stderr io.Writer) int {
+	if len(args) != 1 || args[0] != "clear" {
+		fmt.Fprint(stderr, cacheUsage)
+		return 2
+	}
+
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error locating the cache: %v\n", err)
+		return 1
+	}
+	if err := cache.NewStore(dir).Clear(); err != nil {
+		fmt.Fprintf(stderr, "Error clearing the cache: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "Cleared %s\n", dir)
+	return 0
+}
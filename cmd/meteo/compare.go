@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"meteo/internal/display"
+	"meteo/internal/domain"
+)
+
+func runCompare(args []string, stdout, stderr io.Writer) int {
+	s, code := newSession("compare", "Usage: meteo compare [flags] [place]", args, stderr)
+	if s == nil {
+		return code
+	}
+	defer s.close()
+	cfg := s.cfg
+
+	if cfg.Format != "table" && cfg.Format != "json" {
+		fmt.Fprintf(stderr, "Invalid configuration: format %q is not supported by compare, use table or json\n", cfg.Format)
+		return 1
+	}
+
+	ctx, cancel := s.fetchContext()
+	defer cancel()
+	data, code := s.fetchAll(ctx)
+	if data == nil {
+		return code
+	}
+
+	limits := domain.Thresholds(cfg.CompareThresholds).Convert(s.units)
+	report := &display.CompareReport{
+		Location:   s.location,
+		Thresholds: limits,
+		Comparison: domain.Compare(data, limits),
+	}
+
+	err := s.writeOutput(stdout, func(w io.Writer) error {
+		if cfg.Format == "json" {
+			return display.WriteCompareJSON(w, report)
+		}
+		return display.DisplayCompare(w, report, cfg.Hours)
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "Error rendering forecast: %v\n", err)
+		return 1
+	}
+	return 0
+}
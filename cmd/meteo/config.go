@@ -0,0 +1,166 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"meteo/config"
+	"meteo/internal/services/registry"
+)
+
+const configUsage = `Usage:
+  meteo config init [--config path] [--force]   create a commented config file
+  meteo config show [--config path]             print the effective config
+  meteo config validate [--config path]         check the config
+  meteo config set [--config path] key value    change a key of the config file
+
+Without --config the config file is $METEO_CONFIG, else the first of:
+`
+
+func runConfig(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		printConfigUsage(stderr)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("config "+args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { printConfigUsage(fs.Output()) }
+	path := fs.String("config", "", "path to the config file")
+	force := fs.Bool("force", false, "replace an existing config file")
+
+	operands, err := parseInterspersed(fs, args[1:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	switch {
+	case args[0] == "init" && len(operands) == 0:
+		return configInit(*path, *force, stdout, stderr)
+	case args[0] == "show" && len(operands) == 0:
+		return configShow(*path, stdout, stderr)
+	case args[0] == "validate" && len(operands) == 0:
+		return configValidate(*path, stdout, stderr)
+	case args[0] == "set" && len(operands) == 2:
+		return configSet(*path, operands[0], operands[1], stdout, stderr)
+	default:
+		printConfigUsage(stderr)
+		return 2
+	}
+}
+
+func printConfigUsage(w io.Writer) {
+	fmt.Fprint(w, configUsage)
+	for _, path := range config.SearchPaths() {
+		fmt.Fprintf(w, "  %s\n", path)
+	}
+}
+
+// writablePath returns the config file to create or change: the given
+// path, else the file in use, else the default location.
+func writablePath(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+	if found, err := config.FindConfig(""); err != nil || found != "" {
+		return found, err
+	}
+	return config.DefaultPath()
+}
+
+func configInit(path string, force bool, stdout, stderr io.Writer) int {
+	if path == "" {
+		var err error
+		if path, err = config.DefaultPath(); err != nil {
+			fmt.Fprintf(stderr, "Error locating the config directory: %v\n", err)
+			return 1
+		}
+	}
+	if err := config.WriteExample(path, force); err != nil {
+		fmt.Fprintf(stderr, "Error creating the config: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "Created %s\n", path)
+	fmt.Fprintln(stdout, "Set your location with 'meteo config set latitude <degrees>' and 'meteo config set longitude <degrees>', or 'meteo config set location <place>'.")
+	return 0
+}
+
+func configShow(path string, stdout, stderr io.Writer) int {
+	cfg, err := config.ReadConfig(path)
+	if err != nil {
+		fmt.Fprintf(stderr, "Invalid configuration: %v\n", err)
+		return 1
+	}
+	out, err := cfg.Marshal()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error printing the config: %v\n", err)
+		return 1
+	}
+
+	if cfg.File != "" {
+		fmt.Fprintf(stdout, "# %s\n", cfg.File)
+	} else {
+		fmt.Fprintln(stdout, "# no config file found, defaults and environment only")
+	}
+	stdout.Write(out)
+	return 0
+}
+
+func configValidate(path string, stdout, stderr io.Writer) int {
+	cfg, err := config.ReadConfig(path)
+	if err == nil {
+		err = validateConfig(cfg)
+	}
+	if err != nil {
+		if cfg != nil && cfg.File == "" {
+			fmt.Fprintln(stderr, "No config file found, create one with 'meteo config init'.")
+		}
+		fmt.Fprintf(stderr, "Invalid configuration: %v\n", err)
+		return 1
+	}
+
+	name := cfg.File
+	if name == "" {
+		name = "defaults and environment"
+	}
+	fmt.Fprintf(stdout, "%s: OK\n", name)
+	return 0
+}
+
+// validateConfig checks the config as a forecast would use it with no
+// command-line overrides.
+func validateConfig(cfg *config.Config) error {
+	if cfg.DefaultLocation != "" {
+		if err := cfg.SelectLocation(cfg.DefaultLocation); err != nil {
+			return fmt.Errorf("default-location: %w", err)
+		}
+	}
+	for _, name := range cfg.ProviderChain() {
+		if _, err := registry.Variables(name); err != nil {
+			return err
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	return validateProviders(cfg)
+}
+
+func configSet(path, key, value string, stdout, stderr io.Writer) int {
+	path, err := writablePath(path)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error locating the config: %v\n", err)
+		return 1
+	}
+	if err := config.SetValue(path, key, value); err != nil {
+		fmt.Fprintf(stderr, "Error changing %s: %v\n", path, err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "Set %s in %s\n", key, path)
+	return 0
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"meteo/config"
+	"meteo/internal/display"
+	"meteo/internal/domain"
+	"meteo/internal/services/registry"
+)
+
+func runEnsemble(args []string, stdout, stderr io.Writer) int {
+	s, code := newSession("ensemble", "Usage: meteo ensemble [flags] [place]", args, stderr)
+	if s == nil {
+		return code
+	}
+	defer s.close()
+	cfg := s.cfg
+
+	if cfg.Format != "table" && cfg.Format != "json" {
+		fmt.Fprintf(stderr, "Invalid configuration: format %q is not supported by ensemble, use table or json\n", cfg.Format)
+		return 1
+	}
+
+	ctx, cancel := s.fetchContext()
+	defer cancel()
+	data, code := s.fetchAll(ctx)
+	if data == nil {
+		return code
+	}
+
+	names := make([]string, len(data))
+	for i, d := range data {
+		names[i] = d.Provider
+	}
+	report := &display.EnsembleReport{
+		Location: s.location,
+		Ensemble: domain.NewEnsemble(names, data),
+	}
+
+	err := s.writeOutput(stdout, func(w io.Writer) error {
+		if cfg.Format == "json" {
+			return display.WriteEnsembleJSON(w, report)
+		}
+		return display.DisplayEnsemble(w, report, cfg.Hours)
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "Error rendering forecast: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// multiProviders returns the configured providers when there are several.
+// Otherwise it returns every registered provider whose credentials are set.
+func multiProviders(cfg *config.Config) ([]string, error) {
+	if chain := cfg.ProviderChain(); len(chain) > 1 {
+		return chain, nil
+	}
+	var names []string
+	for _, name := range registry.Names() {
+		if cfg.ValidateProviders([]string{name}) == nil {
+			names = append(names, name)
+		}
+	}
+	if len(names) < 2 {
+		return nil, fmt.Errorf("at least two providers are needed, set providers or the credentials of another provider")
+	}
+	return names, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+
+	"meteo/internal/services"
+)
+
+// failures maps the kinds of provider errors to the exit code of the
+// process and a hint on how to fix them. When several providers failed,
+// the first kind in this order that matches decides.
+var failures = []struct {
+	kind error
+	code int
+	hint string
+}{
+	{
+		kind: services.ErrUnauthorized,
+		code: 3,
+		hint: "the provider rejected the credentials, check meteoblue-api-key and meteoblue-shared-secret in the config",
+	},
+	{
+		kind: services.ErrInvalidLocation,
+		code: 7,
+		hint: "check the place name, or the latitude and longitude",
+	},
+	{
+		kind: services.ErrRateLimited,
+		code: 4,
+		hint: "the request quota of the provider is used up, try again later or choose another provider with --provider",
+	},
+	{
+		kind: services.ErrInvalidResponse,
+		code: 6,
+		hint: "the provider sent data meteo cannot read, try another provider with --provider",
+	},
+	{
+		kind: services.ErrUpstreamUnavailable,
+		code: 5,
+		hint: "the provider is unreachable, check the network connection or try again later",
+	},
+}
+
+// exitUnavailable is also used when fetching the forecast timed out.
+const exitUnavailable = 5
+
+// classify returns the exit code and hint for err, 1 and no hint for errors
+// of no known kind.
+func classify(err error) (int, string) {
+	for _, f := range failures {
+		if errors.Is(err, f.kind) {
+			return f.code, f.hint
+		}
+	}
+	return 1, ""
+}
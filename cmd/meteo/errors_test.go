@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"meteo/internal/domain"
+	"meteo/internal/services"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+		wantHint bool
+	}{
+		{
+			name:     "Unauthorized",
+			err:      services.StatusError("meteoblue", http.StatusForbidden, ""),
+			wantCode: 3,
+			wantHint: true,
+		},
+		{
+			name:     "Rate limited",
+			err:      services.StatusError("openmeteo", http.StatusTooManyRequests, ""),
+			wantCode: 4,
+			wantHint: true,
+		},
+		{
+			name:     "Upstream unavailable",
+			err:      services.StatusError("openmeteo", http.StatusBadGateway, ""),
+			wantCode: 5,
+			wantHint: true,
+		},
+		{
+			name:     "Invalid response",
+			err:      services.ResponseError("openmeteo", domain.ErrMalformed),
+			wantCode: 6,
+			wantHint: true,
+		},
+		{
+			name:     "Invalid location",
+			err:      services.ValidateCoordinates(91, 0),
+			wantCode: 7,
+			wantHint: true,
+		},
+		{
+			name:     "Credentials decide over an outage of the next provider",
+			err:      errors.Join(services.StatusError("openmeteo", http.StatusServiceUnavailable, ""), services.StatusError("meteoblue", http.StatusUnauthorized, "")),
+			wantCode: 3,
+			wantHint: true,
+		},
+		{
+			name:     "Wrapped kind",
+			err:      fmt.Errorf("fetching: %w", services.StatusError("openmeteo", http.StatusTooManyRequests, "")),
+			wantCode: 4,
+			wantHint: true,
+		},
+		{
+			name:     "Unknown error",
+			err:      context.Canceled,
+			wantCode: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, hint := classify(tt.err)
+			if code != tt.wantCode {
+				t.Errorf("classify() code = %d, want %d", code, tt.wantCode)
+			}
+			if (hint != "") != tt.wantHint {
+				t.Errorf("classify() hint = %q, wantHint %v", hint, tt.wantHint)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"meteo/config"
+	"meteo/internal/display"
+)
+
+func runForecast(args []string, stdout, stderr io.Writer) int {
+	s, code := newSession("forecast", "Usage: meteo [forecast] [flags] [place]", args, stderr)
+	if s == nil {
+		return code
+	}
+	defer s.close()
+	cfg := s.cfg
+
+	// Init services.
+	weatherService, err := s.newService(cfg.ProviderChain())
+	if err != nil {
+		fmt.Fprintf(stderr, "Error selecting weather provider: %v\n", err)
+		return 1
+	}
+
+	// Get weather data
+	ctx, cancel := s.fetchContext()
+	defer cancel()
+	weatherData, err := weatherService.Get(ctx, s.location, cfg)
+	if err != nil {
+		return s.fail(ctx, "Error fetching weather data", err)
+	}
+	if notice := display.StaleNotice(weatherData, time.Now()); notice != "" && cfg.Format != "table" {
+		// The table carries the notice itself, other formats are parsed.
+		fmt.Fprintln(stderr, notice)
+	}
+
+	answered := weatherData.Provider
+	if answered == "" {
+		answered = cfg.Provider
+	}
+
+	s.location.UseReportedTimezone(weatherData.Timezone)
+	report := &display.Report{
+		Location: s.location,
+		Provider: answered,
+		Weather:  weatherData.Convert(s.units),
+	}
+	err = s.writeOutput(stdout, func(w io.Writer) error {
+		return render(w, cfg, report)
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "Error rendering forecast: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func render(w io.Writer, cfg *config.Config, report *display.Report) error {
+	switch cfg.Format {
+	case "json":
+		return display.WriteJSON(w, report, cfg.Daily)
+	case "csv":
+		return display.WriteCSV(w, report, ',')
+	case "tsv":
+		return display.WriteCSV(w, report, '\t')
+	default:
+		if report.Location.Name != "" || report.Location.Elevation != nil {
+			fmt.Fprintf(w, "%s\n\n", report.Location)
+		}
+		if len(cfg.ProviderChain()) > 1 {
+			fmt.Fprintf(w, "Forecast by %s\n\n", report.Provider)
+		}
+		if notice := display.StaleNotice(report.Weather, time.Now()); notice != "" {
+			fmt.Fprintf(w, "%s\n\n", notice)
+		}
+		if cfg.Daily {
+			return display.DisplayDaily(w, report)
+		}
+		return display.DisplayTable(w, report, cfg.Hours, cfg.Columns)
+	}
+}
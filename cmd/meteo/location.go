@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"meteo/config"
+	"meteo/internal/domain"
+	"meteo/internal/geocoding"
+	"meteo/internal/geocoding/gazetteer"
+	"meteo/internal/geocoding/openmeteo"
+)
+
+type httpClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+func newGeocoder(name string, client httpClient) (geocoding.Contract, error) {
+	switch name {
+	case "openmeteo":
+		return openmeteo.NewOpenmeteo(client), nil
+	case "offline":
+		return gazetteer.NewGazetteer()
+	default:
+		return nil, fmt.Errorf("unknown geocoder %q", name)
+	}
+}
+
+// resolveLocation geocodes cfg.Location, when set, and stores the resulting
+// coordinates and altitude in cfg. The place is returned for its name and
+// timezone. Ambiguous names are settled by
+// pick (1-based), by asking on an interactive terminal, or reported with the
+// candidates. The lookup is bounded by cfg.Timeout, the question only by
+// ctx.
+func resolveLocation(ctx context.Context, cfg *config.Config, client httpClient, pick int, stderr io.Writer) (*domain.Place, error) {
+	if cfg.Location == "" {
+		return nil, nil
+	}
+
+	geocoder, err := newGeocoder(cfg.Geocoder, client)
+	if err != nil {
+		return nil, err
+	}
+
+	lookupCtx, cancel := withTimeout(ctx, cfg.Timeout)
+	place, err := geocoding.Resolve(lookupCtx, geocoder, cfg.Location)
+	timedOut := ctx.Err() == nil && errors.Is(lookupCtx.Err(), context.DeadlineExceeded)
+	cancel()
+	if timedOut {
+		return nil, errTimedOut
+	}
+
+	var ambiguous *geocoding.AmbiguousError
+	if errors.As(err, &ambiguous) {
+		place, err = choosePlace(ctx, ambiguous, pick, stderr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.SetCoordinates(place.Latitude, place.Longitude)
+	if cfg.Altitude == nil {
+		cfg.Altitude = place.Elevation
+	}
+	return place, nil
+}
+
+func choosePlace(ctx context.Context, ambiguous *geocoding.AmbiguousError, pick int, stderr io.Writer) (*domain.Place, error) {
+	candidates := ambiguous.Candidates
+
+	if pick == 0 && !isTerminal(os.Stdin) {
+		var b strings.Builder
+		fmt.Fprintf(&b, "%v:\n", ambiguous)
+		writeCandidates(&b, candidates)
+		fmt.Fprintf(&b, "narrow it down, e.g. \"%s, %s\", or choose one with --pick", candidates[0].Name, candidates[0].CountryCode)
+		return nil, errors.New(b.String())
+	}
+
+	if pick == 0 {
+		fmt.Fprintf(stderr, "%v:\n", ambiguous)
+		writeCandidates(stderr, candidates)
+		fmt.Fprint(stderr, "Choose a place: ")
+
+		line, err := readLine(ctx, os.Stdin)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil && line == "" {
+			return nil, fmt.Errorf("no place chosen: %w", err)
+		}
+		pick, err = strconv.Atoi(strings.TrimSpace(line))
+		if err != nil {
+			return nil, fmt.Errorf("invalid choice %q", strings.TrimSpace(line))
+		}
+	}
+
+	if pick < 1 || pick > len(candidates) {
+		return nil, fmt.Errorf("choice %d out of range 1-%d", pick, len(candidates))
+	}
+	return geocoding.Validate(&candidates[pick-1])
+}
+
+func writeCandidates(w io.Writer, candidates []domain.Place) {
+	for i, p := range candidates {
+		fmt.Fprintf(w, "  %d) %s (%.4f, %.4f)\n", i+1, p, p.Latitude, p.Longitude)
+	}
+}
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// readLine reads a line from r, or gives up when ctx is done, e.g. on
+// Ctrl-C. The read itself cannot be interrupted and is left behind then,
+// which is harmless as the process is about to exit.
+func readLine(ctx context.Context, r io.Reader) (string, error) {
+	type result struct {
+		line string
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		line, err := bufio.NewReader(r).ReadString('\n')
+		done <- result{line, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case res := <-done:
+		return res.line, res.err
+	}
+}
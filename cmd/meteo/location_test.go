@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadLine(t *testing.T) {
+	line, err := readLine(context.Background(), strings.NewReader("2\nrest"))
+	if line != "2\n" || err != nil {
+		t.Errorf("readLine() = %q, %v, want \"2\\n\"", line, err)
+	}
+
+	// A pipe nobody writes to blocks like a terminal waiting for input.
+	r, w := io.Pipe()
+	defer w.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := readLine(ctx, r); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("readLine() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -2,35 +2,50 @@ package main
 
 import (
 	"fmt"
-	"net/http"
+	"io"
 	"os"
-
-	"meteo/config"
-	"meteo/internal/display"
-	"meteo/internal/services/meteoblue"
-
-	"github.com/zsefvlol/timezonemapper"
 )
 
-func main() {
-	cfg := config.ReadConfig()
+const usage = `meteo - weather forecast in the terminal
 
-	// Init http client.
-	httpClient := &http.Client{}
+Usage:
+  meteo [forecast] [flags] [place]   show the forecast (default command)
+  meteo ensemble [flags] [place]     show the mean and spread of several providers
+  meteo compare [flags] [place]      show the forecasts of several providers side by side
+  meteo config init|show|validate|set
+                                     create, print, check or change the config file
+  meteo cache clear                  remove all cached forecasts
+  meteo version                      print the version
 
-	// Init services.
-	// Possible weather provider: openmeteo or meteoblue
-	// weatherService := openmeteo.NewOpenmeteo(httpClient)
-	weatherService := meteoblue.NewMeteoblue(httpClient)
+Run 'meteo forecast --help' for the list of flags.
+`
 
-	// Get weather data
-	weatherData, err := weatherService.Get(cfg)
-	if err != nil {
-		fmt.Printf("Error fetching weather data: %v\n", err)
-		os.Exit(1)
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		return runForecast(args, stdout, stderr)
 	}
 
-	// Render table
-	timezone := timezonemapper.LatLngToTimezoneString(cfg.Latitude, cfg.Longitude)
-	display.DisplayTable(weatherData, timezone)
+	switch args[0] {
+	case "forecast":
+		return runForecast(args[1:], stdout, stderr)
+	case "ensemble":
+		return runEnsemble(args[1:], stdout, stderr)
+	case "compare":
+		return runCompare(args[1:], stdout, stderr)
+	case "config":
+		return runConfig(args[1:], stdout, stderr)
+	case "cache":
+		return runCache(args[1:], stdout, stderr)
+	case "version":
+		return runVersion(stdout)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	default:
+		return runForecast(args, stdout, stderr)
+	}
 }
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "Version",
+			args:       []string{"version"},
+			wantStdout: "meteo dev\n",
+		},
+		{
+			name:       "Help",
+			args:       []string{"--help"},
+			wantStdout: usage,
+		},
+		{
+			name:       "Help of a command",
+			args:       []string{"forecast", "--help"},
+			wantStderr: "Usage: meteo [forecast] [flags] [place]\n",
+		},
+		{
+			name:       "Unknown flag",
+			args:       []string{"compare", "--weeks", "2"},
+			wantCode:   2,
+			wantStderr: "flag provided but not defined: -weeks",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, &stdout, &stderr); code != tt.wantCode {
+				t.Errorf("run() = %d, want %d", code, tt.wantCode)
+			}
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", stdout.String(), tt.wantStdout)
+			}
+			if !strings.HasPrefix(stderr.String(), tt.wantStderr) {
+				t.Errorf("stderr = %q, want prefix %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
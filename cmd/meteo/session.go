@@ -0,0 +1,464 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"meteo/config"
+	"meteo/internal/display"
+	"meteo/internal/domain"
+	"meteo/internal/elevation"
+	openmeteoelevation "meteo/internal/elevation/openmeteo"
+	"meteo/internal/geocoding"
+	"meteo/internal/services"
+	"meteo/internal/services/cache"
+	"meteo/internal/services/failover"
+	"meteo/internal/services/fanout"
+	"meteo/internal/services/registry"
+	"meteo/internal/services/retry"
+)
+
+// session holds what the commands fetching forecasts share: the final
+// config with command-line overrides applied, the resolved location and
+// the HTTP client.
+type session struct {
+	cfg      *config.Config
+	ctx      context.Context
+	stop     context.CancelFunc
+	client   httpClient
+	location domain.Location
+	units    domain.Units
+	noCache  bool
+	output   string
+	stderr   io.Writer
+}
+
+// newSession parses the flags common to all forecast commands, loads the
+// config and resolves the location. When it returns a nil session, the
+// command exits with the returned code.
+func newSession(name, usage string, args []string, stderr io.Writer) (*session, int) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "%s\n\nFlags:\n", usage)
+		fs.PrintDefaults()
+	}
+
+	configPath := fs.String("config", "", "path to the config file")
+	lat := fs.Float64("lat", 0, "latitude in degrees, overrides the config")
+	lon := fs.Float64("lon", 0, "longitude in degrees, overrides the config")
+	alt := fs.Float64("alt", 0, "altitude in metres above sea level, looked up when not given")
+	days := fs.Int("days", config.DefaultDays, daysUsage())
+	hours := fs.Int("hours", config.DefaultHours, "number of upcoming hours to show, 0 for the whole forecast")
+	provider := fs.String("provider", config.DefaultProvider, "weather provider, or a comma-separated list: "+strings.Join(registry.Names(), ", "))
+	format := fs.String("format", config.DefaultFormat, "output format: table, json, csv or tsv")
+	output := fs.String("output", "", "write the forecast to this file instead of stdout")
+	geocoder := fs.String("geocoder", config.DefaultGeocoder, "place name lookup: openmeteo or offline")
+	pick := fs.Int("pick", 0, "choose the n-th place when the place name is ambiguous")
+	location := fs.String("location", "", "name of a saved location from the config")
+	units := fs.String("units", config.DefaultUnits, "unit system: metric, imperial or custom")
+	columns := fs.String("columns", strings.Join(config.DefaultColumns, ","), "comma-separated columns of the hourly table")
+	daily := fs.Bool("daily", false, "show one summary row per day instead of hourly rows")
+	noCache := fs.Bool("no-cache", false, "always fetch a fresh forecast from the provider")
+	timeout := fs.Duration("timeout", config.DefaultTimeout, "upper bound for fetching the forecast and for each lookup before it, 0 disables it")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, 0
+		}
+		return nil, 2
+	}
+	place := strings.Join(positional, " ")
+
+	coordinatesSet := isFlagSet(fs, "lat") || isFlagSet(fs, "lon")
+	if *location != "" && (place != "" || coordinatesSet) {
+		fmt.Fprintln(stderr, "--location cannot be combined with a place name or --lat and --lon")
+		return nil, 2
+	}
+	if place != "" && coordinatesSet {
+		fmt.Fprintln(stderr, "a place name cannot be combined with --lat and --lon")
+		return nil, 2
+	}
+
+	cfg, err := config.ReadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "Invalid configuration: %v\n", err)
+		return nil, 1
+	}
+
+	// A saved location is used when selected explicitly, or by default when
+	// no other location is given on the command line.
+	savedLocation := *location
+	if savedLocation == "" && place == "" && !coordinatesSet {
+		savedLocation = cfg.DefaultLocation
+	}
+	if savedLocation != "" {
+		if err := cfg.SelectLocation(savedLocation); err != nil {
+			fmt.Fprintf(stderr, "Invalid configuration: %v\n", err)
+			return nil, 1
+		}
+	}
+
+	// Flags take precedence over the config file, but only when given
+	// explicitly, so that zero values remain meaningful.
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "lat":
+			cfg.Latitude = lat
+			cfg.Altitude = nil
+			cfg.Location = ""
+			cfg.Timezone = ""
+		case "lon":
+			cfg.Longitude = lon
+			cfg.Altitude = nil
+			cfg.Location = ""
+			cfg.Timezone = ""
+		case "days":
+			cfg.Days = *days
+		case "hours":
+			cfg.Hours = *hours
+		case "provider":
+			cfg.SetProviders(*provider)
+		case "format":
+			cfg.Format = *format
+		case "geocoder":
+			cfg.Geocoder = *geocoder
+		case "units":
+			cfg.Units = *units
+		case "columns":
+			cfg.SetColumns(*columns)
+		case "daily":
+			cfg.Daily = *daily
+		case "timeout":
+			cfg.Timeout = *timeout
+		}
+	})
+	if place != "" {
+		cfg.Location = place
+		cfg.Altitude = nil
+		cfg.Timezone = ""
+	}
+	// Applied last, since other locations reset the altitude.
+	if isFlagSet(fs, "alt") {
+		cfg.Altitude = alt
+	}
+
+	// Cancel pending requests on Ctrl-C.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	s := &session{
+		cfg:     cfg,
+		ctx:     ctx,
+		stop:    stop,
+		client:  retry.NewClient(&http.Client{Timeout: cfg.RequestTimeout}, cfg.Retries),
+		noCache: *noCache,
+		output:  *output,
+		stderr:  stderr,
+	}
+
+	// Checked before any request, the place name stands in for the
+	// coordinates until it is resolved.
+	err = cfg.Validate()
+	if err == nil {
+		err = validateProviders(cfg)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Invalid configuration: %v\n", err)
+		if cfg.File == "" {
+			fmt.Fprintln(stderr, "No config file found, create one with 'meteo config init'.")
+		}
+		s.close()
+		return nil, 1
+	}
+
+	resolved, err := resolveLocation(ctx, cfg, s.client, *pick, stderr)
+	if err != nil {
+		code := s.fail(ctx, "Error resolving location", err)
+		s.close()
+		return nil, code
+	}
+
+	// The configured timezone overrides the one of the place, which in turn
+	// may be replaced by the one the provider reports.
+	name, reported := savedLocation, ""
+	if resolved != nil {
+		name, reported = resolved.String(), resolved.Timezone
+	}
+	latitude, longitude := cfg.Coordinates()
+	s.location = geocoding.NewLocation(name, latitude, longitude, cfg.Timezone, reported)
+	s.location.Elevation = cfg.Altitude
+	if s.location.Elevation == nil && cfg.ElevationLookup {
+		s.lookupElevation()
+	}
+
+	s.units = unitsOf(cfg)
+	return s, 0
+}
+
+// validateProviders checks that every provider of the chain serves the
+// requested forecast days and supplies the selected columns.
+func validateProviders(cfg *config.Config) error {
+	providers := cfg.ProviderChain()
+	for _, name := range providers {
+		maxDays, err := registry.MaxForecastDays(name)
+		if err != nil {
+			return err
+		}
+		if cfg.Days > maxDays {
+			return fmt.Errorf("days (%d) exceed the %d days served by the %s provider", cfg.Days, maxDays, name)
+		}
+	}
+	return validateColumns(cfg.Columns, providers)
+}
+
+// daysUsage returns the help text of the --days flag, naming the limit of
+// each provider.
+func daysUsage() string {
+	var limits []string
+	for _, name := range registry.Names() {
+		maxDays, _ := registry.MaxForecastDays(name)
+		limits = append(limits, fmt.Sprintf("%s: up to %d", name, maxDays))
+	}
+	return "number of forecast days to request (" + strings.Join(limits, ", ") + ")"
+}
+
+// validateColumns checks that every column exists and is supplied by all
+// of the providers.
+func validateColumns(columns, providers []string) error {
+	for _, column := range columns {
+		if !display.IsColumn(column) {
+			return fmt.Errorf("unknown column %q", column)
+		}
+		if column == display.ConditionColumn {
+			continue
+		}
+		for _, name := range providers {
+			variables, err := registry.Variables(name)
+			if err != nil {
+				return err
+			}
+			if !slices.Contains(variables, domain.Variable(column)) {
+				return fmt.Errorf("column %q is not supplied by the %s provider", column, name)
+			}
+		}
+	}
+	return nil
+}
+
+// unitsOf returns the unit system selected by the config.
+func unitsOf(cfg *config.Config) domain.Units {
+	switch cfg.Units {
+	case "imperial":
+		return domain.Imperial
+	case "custom":
+		return domain.Units{
+			Temperature:   domain.TemperatureUnit(cfg.CustomUnits.Temperature),
+			WindSpeed:     domain.WindSpeedUnit(cfg.CustomUnits.WindSpeed),
+			Precipitation: domain.PrecipitationUnit(cfg.CustomUnits.Precipitation),
+		}
+	default:
+		return domain.Metric
+	}
+}
+
+// lookupElevation sets the elevation of the location from the elevation
+// model. Without it the providers use their own terrain models, so a failed
+// lookup is only reported. It is not retried, to leave the time to the
+// forecast fetch when the network is down, and is bounded by the timeout of
+// the fetch, since request-timeout may be 0.
+func (s *session) lookupElevation() {
+	ctx, cancel := s.fetchContext()
+	defer cancel()
+
+	client := &http.Client{Timeout: s.cfg.RequestTimeout}
+	var service elevation.Contract = openmeteoelevation.NewOpenmeteo(client)
+	if dir, err := cache.DefaultDir(); err == nil {
+		service = cache.NewElevation(service, cache.NewStore(dir))
+	}
+
+	alt, err := service.Lookup(ctx, s.location.Latitude, s.location.Longitude)
+	if err != nil {
+		// An interruption is reported by the forecast fetch.
+		if s.ctx.Err() == nil {
+			fmt.Fprintf(s.stderr, "Warning: elevation lookup failed: %v\n", err)
+		}
+		return
+	}
+	s.location.Elevation = &alt
+}
+
+func (s *session) close() {
+	s.stop()
+}
+
+// fetchContext bounds the forecast fetch by the configured timeout.
+func (s *session) fetchContext() (context.Context, context.CancelFunc) {
+	return withTimeout(s.ctx, s.cfg.Timeout)
+}
+
+// withTimeout bounds ctx by timeout, unless it is 0.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// errTimedOut is returned when a lookup before the forecast fetch, bounded
+// by the same timeout, ran out of time.
+var errTimedOut = errors.New("timed out")
+
+// newService returns the given provider, or a failover chain when several
+// names are given, wrapped by the forecast cache.
+func (s *session) newService(names []string) (services.Contract, error) {
+	var service services.Contract
+	if len(names) == 1 {
+		var err error
+		if service, err = registry.New(names[0], s.client); err != nil {
+			return nil, err
+		}
+	} else {
+		chain := make([]failover.Provider, len(names))
+		for i, name := range names {
+			member, err := registry.New(name, s.client)
+			if err != nil {
+				return nil, err
+			}
+			chain[i] = failover.Provider{Name: name, Service: member}
+		}
+		service = failover.New(chain)
+	}
+
+	// The cache also keeps the last forecast of each location as a fallback
+	// for when the provider is unreachable, so it stays in place even when
+	// fresh data is requested.
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		fmt.Fprintf(s.stderr, "Warning: cache disabled: %v\n", err)
+		return service, nil
+	}
+	ttl := s.cfg.CacheTTL
+	if s.noCache {
+		ttl = 0
+	}
+	return cache.New(service, strings.Join(names, ","), cache.NewStore(dir), ttl), nil
+}
+
+// fetchAll fetches the forecasts of several providers concurrently. When
+// it returns nil, the command exits with the returned code.
+func (s *session) fetchAll(ctx context.Context) ([]*domain.WeatherData, int) {
+	names, err := multiProviders(s.cfg)
+	if err != nil {
+		fmt.Fprintf(s.stderr, "Invalid configuration: %v\n", err)
+		return nil, 1
+	}
+
+	members := make([]fanout.Member, len(names))
+	for i, name := range names {
+		service, err := s.newService([]string{name})
+		if err != nil {
+			fmt.Fprintf(s.stderr, "Error selecting weather provider: %v\n", err)
+			return nil, 1
+		}
+		members[i] = fanout.Member{Name: name, Service: service}
+	}
+
+	data, err := fanout.Fetch(ctx, s.location, s.cfg, members)
+	if err != nil {
+		return nil, s.fail(ctx, "Error fetching weather data", err)
+	}
+	// The providers agree on the timezone, the first one reporting it is
+	// taken.
+	for _, d := range data {
+		if d.Timezone != "" {
+			s.location.UseReportedTimezone(d.Timezone)
+			break
+		}
+	}
+	for i, d := range data {
+		if notice := display.StaleNotice(d, time.Now()); notice != "" {
+			fmt.Fprintf(s.stderr, "%s: %s\n", d.Provider, notice)
+		}
+		data[i] = d.Convert(s.units)
+	}
+	return data, 0
+}
+
+// writeOutput calls write with the output file, or with stdout when no
+// file was requested.
+func (s *session) writeOutput(stdout io.Writer, write func(w io.Writer) error) error {
+	if s.output == "" {
+		return write(stdout)
+	}
+
+	f, err := os.Create(s.output)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// fail reports err and returns the exit code, telling interruptions and
+// timeouts of ctx apart from the kinds of provider errors in failures.
+func (s *session) fail(ctx context.Context, what string, err error) int {
+	switch {
+	case errors.Is(ctx.Err(), context.Canceled):
+		fmt.Fprintln(s.stderr, "Interrupted")
+		return 130
+	case errors.Is(ctx.Err(), context.DeadlineExceeded), errors.Is(err, errTimedOut):
+		fmt.Fprintf(s.stderr, "%s: timed out, consider raising the timeout\n", what)
+		return exitUnavailable
+	}
+	fmt.Fprintf(s.stderr, "%s: %v\n", what, err)
+	code, hint := classify(err)
+	if hint != "" {
+		fmt.Fprintf(s.stderr, "Hint: %s\n", hint)
+	}
+	return code
+}
+
+// parseInterspersed parses flags given before, between or after the
+// positional arguments, which it returns. Arguments after "--" are all
+// positional.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		if len(rest) < len(args) && args[len(args)-len(rest)-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+func isFlagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
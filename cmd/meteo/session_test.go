@@ -0,0 +1,382 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"meteo/config"
+	"meteo/internal/services"
+)
+
+func TestValidateProviders(t *testing.T) {
+	tests := []struct {
+		name      string
+		providers []string
+		days      int
+		columns   []string
+		wantErr   string
+	}{
+		{
+			name:      "Days served by all providers",
+			providers: []string{"meteoblue", "openmeteo"},
+			days:      14,
+			columns:   config.DefaultColumns,
+		},
+		{
+			name:      "Days beyond one provider of the chain",
+			providers: []string{"openmeteo", "meteoblue"},
+			days:      16,
+			columns:   config.DefaultColumns,
+			wantErr:   "days (16) exceed the 14 days served by the meteoblue provider",
+		},
+		{
+			name:      "Unknown provider",
+			providers: []string{"openmeteo", "nowhere"},
+			days:      3,
+			columns:   config.DefaultColumns,
+			wantErr:   `unknown provider "nowhere", available: meteoblue, openmeteo`,
+		},
+		{
+			name:      "Unknown column",
+			providers: []string{"openmeteo"},
+			days:      3,
+			columns:   []string{"snow"},
+			wantErr:   `unknown column "snow"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Days: tt.days, Columns: tt.columns}
+			cfg.SetProviders(strings.Join(tt.providers, ","))
+			err := validateProviders(cfg)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateProviders() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("validateProviders() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseInterspersed(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		wantPositional []string
+		wantDays       int
+		wantErr        bool
+	}{
+		{
+			name:           "Flags before the place name",
+			args:           []string{"--days", "5", "New", "York"},
+			wantPositional: []string{"New", "York"},
+			wantDays:       5,
+		},
+		{
+			name:           "Flags after the place name",
+			args:           []string{"New", "York", "--days", "5"},
+			wantPositional: []string{"New", "York"},
+			wantDays:       5,
+		},
+		{
+			name:           "Flags between the words",
+			args:           []string{"New", "-days=5", "York"},
+			wantPositional: []string{"New", "York"},
+			wantDays:       5,
+		},
+		{
+			name:           "Arguments after -- are positional",
+			args:           []string{"--days", "5", "--", "-Paris", "--days", "2"},
+			wantPositional: []string{"-Paris", "--days", "2"},
+			wantDays:       5,
+		},
+		{
+			name:           "Place name before --",
+			args:           []string{"York", "--", "--days"},
+			wantPositional: []string{"York", "--days"},
+			wantDays:       1,
+		},
+		{
+			name:     "No arguments",
+			wantDays: 1,
+		},
+		{
+			name:    "Unknown flag",
+			args:    []string{"Paris", "--weeks", "2"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("forecast", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			days := fs.Int("days", 1, "")
+
+			positional, err := parseInterspersed(fs, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseInterspersed() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(positional, tt.wantPositional) {
+				t.Errorf("parseInterspersed() = %q, want %q", positional, tt.wantPositional)
+			}
+			if *days != tt.wantDays {
+				t.Errorf("days = %d, want %d", *days, tt.wantDays)
+			}
+		})
+	}
+}
+
+// settings are the parts of the final config a forecast depends on.
+type settings struct {
+	Days      int
+	Hours     int
+	Format    string
+	Providers []string
+	Latitude  float64
+	Longitude float64
+	Altitude  *float64
+	Location  string
+	Timezone  string
+}
+
+func settingsOf(cfg *config.Config) settings {
+	lat, lng := cfg.Coordinates()
+	return settings{
+		Days:      cfg.Days,
+		Hours:     cfg.Hours,
+		Format:    cfg.Format,
+		Providers: cfg.ProviderChain(),
+		Latitude:  lat,
+		Longitude: lng,
+		Altitude:  cfg.Altitude,
+		Location:  cfg.Location,
+		Timezone:  cfg.Timezone,
+	}
+}
+
+func TestNewSession(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	file := `latitude: 52.52
+longitude: 13.41
+altitude: 34
+timezone: Europe/Berlin
+days: 5
+hours: 24
+format: json
+elevation-lookup: false
+locations:
+  office:
+    latitude: 48.14
+    longitude: 11.58
+  cabin:
+    latitude: 61.5
+    longitude: 8.0
+    provider: meteoblue
+`
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		want       settings
+		wantCode   int
+		wantStderr string
+	}{
+		{
+			name: "Config without flags",
+			want: settings{
+				Days: 5, Hours: 24, Format: "json", Providers: []string{"openmeteo"},
+				Latitude: 52.52, Longitude: 13.41, Altitude: ptr(34.0), Timezone: "Europe/Berlin",
+			},
+		},
+		{
+			name: "Flags given with their default value override the config",
+			args: []string{"--days", "3", "--hours", "0", "--format", "table"},
+			want: settings{
+				Days: 3, Hours: 0, Format: "table", Providers: []string{"openmeteo"},
+				Latitude: 52.52, Longitude: 13.41, Altitude: ptr(34.0), Timezone: "Europe/Berlin",
+			},
+		},
+		{
+			name: "Coordinates reset the altitude and timezone of the config",
+			args: []string{"--lat", "-33.87", "--lon", "151.21"},
+			want: settings{
+				Days: 5, Hours: 24, Format: "json", Providers: []string{"openmeteo"},
+				Latitude: -33.87, Longitude: 151.21,
+			},
+		},
+		{
+			name: "Altitude given with the coordinates",
+			args: []string{"--alt", "58", "--lat", "-33.87", "--lon", "151.21"},
+			want: settings{
+				Days: 5, Hours: 24, Format: "json", Providers: []string{"openmeteo"},
+				Latitude: -33.87, Longitude: 151.21, Altitude: ptr(58.0),
+			},
+		},
+		{
+			name: "Saved location",
+			args: []string{"--location", "office"},
+			want: settings{
+				Days: 5, Hours: 24, Format: "json", Providers: []string{"openmeteo"},
+				Latitude: 48.14, Longitude: 11.58,
+			},
+		},
+		{
+			name: "Provider flag over the provider of the saved location",
+			args: []string{"--location", "cabin", "--provider", "openmeteo"},
+			want: settings{
+				Days: 5, Hours: 24, Format: "json", Providers: []string{"openmeteo"},
+				Latitude: 61.5, Longitude: 8.0,
+			},
+		},
+		{
+			name: "Flags after the place name",
+			args: []string{"New", "York", "--geocoder", "offline", "--days", "2"},
+			want: settings{
+				Days: 2, Hours: 24, Format: "json", Providers: []string{"openmeteo"},
+				Latitude: 40.71427, Longitude: -74.00597, Location: "New York",
+			},
+		},
+		{
+			name:     "Help",
+			args:     []string{"--help"},
+			wantCode: 0,
+		},
+		{
+			name:       "Unknown flag",
+			args:       []string{"--weeks", "2"},
+			wantCode:   2,
+			wantStderr: "flag provided but not defined: -weeks\n",
+		},
+		{
+			name:       "Place name and coordinates",
+			args:       []string{"Paris", "--lat", "48.85"},
+			wantCode:   2,
+			wantStderr: "a place name cannot be combined with --lat and --lon\n",
+		},
+		{
+			name:       "Saved location and place name",
+			args:       []string{"--location", "office", "Paris"},
+			wantCode:   2,
+			wantStderr: "--location cannot be combined with a place name or --lat and --lon\n",
+		},
+		{
+			name:       "Unknown saved location",
+			args:       []string{"--location", "home"},
+			wantCode:   1,
+			wantStderr: "Invalid configuration: ",
+		},
+		{
+			name:       "Too many days for the provider",
+			args:       []string{"--days", "17"},
+			wantCode:   1,
+			wantStderr: "Invalid configuration: days (17) exceed the 16 days served by the openmeteo provider\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			s, code := newSession("forecast", "usage", append([]string{"--config", path}, tt.args...), &stderr)
+			if s != nil {
+				defer s.close()
+			}
+			if code != tt.wantCode {
+				t.Fatalf("newSession() code = %d, want %d, stderr %q", code, tt.wantCode, stderr.String())
+			}
+			if tt.wantStderr != "" && !strings.HasPrefix(stderr.String(), tt.wantStderr) {
+				t.Errorf("stderr = %q, want prefix %q", stderr.String(), tt.wantStderr)
+			}
+			if tt.want.Providers == nil {
+				if s != nil {
+					t.Errorf("newSession() returned a session")
+				}
+				return
+			}
+			if s == nil {
+				t.Fatalf("newSession() returned no session, stderr %q", stderr.String())
+			}
+			if got := settingsOf(s.cfg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newSession() settings = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSession_fail(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		err        error
+		wantCode   int
+		wantStderr string
+	}{
+		{
+			name:       "Interrupted",
+			ctx:        canceled,
+			err:        context.Canceled,
+			wantCode:   130,
+			wantStderr: "Interrupted\n",
+		},
+		{
+			name:       "Timed out",
+			ctx:        context.Background(),
+			err:        errTimedOut,
+			wantCode:   exitUnavailable,
+			wantStderr: "Error fetching forecast: timed out, consider raising the timeout\n",
+		},
+		{
+			name:       "Rejected credentials",
+			ctx:        context.Background(),
+			err:        services.StatusError("meteoblue", 401, ""),
+			wantCode:   3,
+			wantStderr: "Error fetching forecast: meteoblue: unauthorized (HTTP 401)\nHint: the provider rejected the credentials",
+		},
+		{
+			name:       "Unknown error",
+			ctx:        context.Background(),
+			err:        errors.New("disk full"),
+			wantCode:   1,
+			wantStderr: "Error fetching forecast: disk full\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			s := &session{stderr: &stderr}
+			if code := s.fail(tt.ctx, "Error fetching forecast", tt.err); code != tt.wantCode {
+				t.Errorf("fail() = %d, want %d", code, tt.wantCode)
+			}
+			if !strings.HasPrefix(stderr.String(), tt.wantStderr) {
+				t.Errorf("stderr = %q, want prefix %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// version is overridden at build time with
+// -ldflags "-X main.version=<version>".
+var version = "dev"
+
+func runVersion(stdout io.Writer) int {
+	fmt.Fprintf(stdout, "meteo %s\n", version)
+	return 0
+}
@@ -1,37 +1,369 @@
 package config
 
 import (
-	"log"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"meteo/internal/domain"
 
 	"github.com/go-playground/validator"
 	"github.com/spf13/viper"
 )
 
+const (
+	DefaultProvider = "openmeteo"
+	DefaultDays     = 3
+	DefaultHours    = 12
+	DefaultFormat   = "table"
+	DefaultGeocoder = "openmeteo"
+	DefaultUnits    = "metric"
+
+	DefaultElevationLookup = true
+
+	DefaultRetries        = 3
+	DefaultRequestTimeout = 10 * time.Second
+	DefaultTimeout        = 30 * time.Second
+	DefaultCacheTTL       = 15 * time.Minute
+
+	DefaultTemperatureThreshold   = 2.0
+	DefaultPrecipitationThreshold = 20.0
+	DefaultWindSpeedThreshold     = 5.0
+)
+
+// DefaultColumns are the columns of the hourly table after the time.
+var DefaultColumns = []string{"temperature", "precipitation_probability", "wind_speed", "condition"}
+
 type Config struct {
-	Latitude                 float64 `mapstructure:"latitude" validate:"required"`
-	Longitude                float64 `mapstructure:"longitude" validate:"required"`
-	MeteoblueAPIKey          string  `mapstructure:"meteoblue-api-key" validate:"required"`
-	MeteoblueAPISharedSecret string  `mapstructure:"meteoblue-shared-secret" validate:"required"`
+	// File is the config file read, empty when none was found.
+	File string `mapstructure:"-"`
+
+	// Latitude and Longitude are required unless Location is set; a nil
+	// value is unset, unlike 0 on the equator or the prime meridian.
+	// Altitude, in metres above sea level, is optional.
+	Latitude  *float64 `mapstructure:"latitude"`
+	Longitude *float64 `mapstructure:"longitude"`
+	Altitude  *float64 `mapstructure:"altitude"`
+	Location  string   `mapstructure:"location"`
+
+	// ElevationLookup looks up the altitude of the location when it is not
+	// given, so that the providers adjust the forecast to the terrain.
+	ElevationLookup bool `mapstructure:"elevation-lookup"`
+
+	Timezone  string   `mapstructure:"timezone"`
+	Geocoder  string   `mapstructure:"geocoder" validate:"oneof=openmeteo offline"`
+	Provider  string   `mapstructure:"provider" validate:"required"`
+	Providers []string `mapstructure:"providers" validate:"dive,required"`
+	Days      int      `mapstructure:"days" validate:"min=1"`
+	Hours     int      `mapstructure:"hours" validate:"min=0"`
+	Format    string   `mapstructure:"format" validate:"oneof=table json csv tsv"`
+	Daily     bool     `mapstructure:"daily"`
+
+	// Columns selects and orders the columns of the hourly table, named
+	// after the weather variables plus "condition".
+	Columns []string `mapstructure:"columns" validate:"min=1,dive,required"`
+
+	// Units is metric, imperial or custom, which takes the unit of every
+	// quantity from CustomUnits.
+	Units       string      `mapstructure:"units" validate:"oneof=metric imperial custom"`
+	CustomUnits CustomUnits `mapstructure:"custom-units"`
+
+	MeteoblueAPIKey          string `mapstructure:"meteoblue-api-key"`
+	MeteoblueAPISharedSecret string `mapstructure:"meteoblue-shared-secret"`
+
+	// The meteoblue credentials may instead be read from a file or printed
+	// by a command, see ResolveSecrets.
+	MeteoblueAPIKeyFile             string `mapstructure:"meteoblue-api-key-file"`
+	MeteoblueAPIKeyCommand          string `mapstructure:"meteoblue-api-key-command"`
+	MeteoblueAPISharedSecretFile    string `mapstructure:"meteoblue-shared-secret-file"`
+	MeteoblueAPISharedSecretCommand string `mapstructure:"meteoblue-shared-secret-command"`
+
+	// Retries is the number of times a failed request is repeated.
+	Retries int `mapstructure:"retries" validate:"min=0"`
+
+	// RequestTimeout bounds a single HTTP request, Timeout the whole
+	// forecast fetch. Zero disables the limit.
+	RequestTimeout time.Duration `mapstructure:"request-timeout" validate:"min=0"`
+	Timeout        time.Duration `mapstructure:"timeout" validate:"min=0"`
+
+	// CacheTTL is how long a fetched forecast is reused. Zero always
+	// fetches a fresh one.
+	CacheTTL time.Duration `mapstructure:"cache-ttl" validate:"min=0"`
+
+	Locations       map[string]SavedLocation `mapstructure:"locations" validate:"dive"`
+	DefaultLocation string                   `mapstructure:"default-location"`
+
+	// CompareThresholds are the differences between providers above which
+	// meteo compare highlights an hour.
+	CompareThresholds Thresholds `mapstructure:"compare-thresholds"`
 }
 
-func ReadConfig() *Config {
-	vp := viper.New()
-	vp.AddConfigPath("config")
-	vp.AddConfigPath("../config")
-	vp.AddConfigPath("../../config")
+// CustomUnits selects the unit of each quantity. Empty fields fall back to
+// the metric unit.
+type CustomUnits struct {
+	Temperature   string `mapstructure:"temperature" validate:"omitempty,oneof=C F K"`
+	WindSpeed     string `mapstructure:"wind-speed" validate:"omitempty,oneof=kmh ms mph kn bft"`
+	Precipitation string `mapstructure:"precipitation" validate:"omitempty,oneof=mm in"`
+}
+
+// Thresholds hold one limit per forecast quantity, in the metric units the
+// providers deliver. They are converted to the selected units.
+type Thresholds struct {
+	Temperature              float64 `mapstructure:"temperature" validate:"min=0"`
+	PrecipitationProbability float64 `mapstructure:"precipitation-probability" validate:"min=0"`
+	WindSpeed                float64 `mapstructure:"wind-speed" validate:"min=0"`
+}
+
+// SavedLocation is a named entry of the locations map. Altitude, Timezone
+// and Provider are optional and replace the top-level values when the entry
+// is selected; Provider may be a comma-separated failover chain.
+type SavedLocation struct {
+	Latitude  *float64 `mapstructure:"latitude"`
+	Longitude *float64 `mapstructure:"longitude"`
+	Altitude  *float64 `mapstructure:"altitude"`
+	Timezone  string   `mapstructure:"timezone"`
+	Provider  string   `mapstructure:"provider"`
+}
 
-	var cfg Config
+// ReadConfig loads the config from path, or from the first config file found
+// by FindConfig when path is empty. Without a config file the defaults
+// apply. METEO_* environment variables take precedence over the file, see
+// EnvPrefix.
+func ReadConfig(path string) (*Config, error) {
+	file, err := FindConfig(path)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := vp.ReadInConfig(); err != nil {
-		log.Fatalf("Read error %v", err)
+	vp := viper.New()
+	bindEnv(vp)
+	vp.SetDefault("provider", DefaultProvider)
+	vp.SetDefault("days", DefaultDays)
+	vp.SetDefault("hours", DefaultHours)
+	vp.SetDefault("format", DefaultFormat)
+	vp.SetDefault("geocoder", DefaultGeocoder)
+	vp.SetDefault("units", DefaultUnits)
+	vp.SetDefault("elevation-lookup", DefaultElevationLookup)
+	vp.SetDefault("columns", DefaultColumns)
+	vp.SetDefault("retries", DefaultRetries)
+	vp.SetDefault("request-timeout", DefaultRequestTimeout)
+	vp.SetDefault("timeout", DefaultTimeout)
+	vp.SetDefault("cache-ttl", DefaultCacheTTL)
+	vp.SetDefault("compare-thresholds.temperature", DefaultTemperatureThreshold)
+	vp.SetDefault("compare-thresholds.precipitation-probability", DefaultPrecipitationThreshold)
+	vp.SetDefault("compare-thresholds.wind-speed", DefaultWindSpeedThreshold)
+
+	if file != "" {
+		vp.SetConfigFile(file)
+		vp.SetConfigType("yaml")
+		if err := vp.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("reading %s: %w", file, err)
+		}
 	}
+
+	cfg := Config{File: file}
 	if err := vp.Unmarshal(&cfg); err != nil {
-		log.Fatalf("unable to unmarshall the config %v", err)
+		if file == "" {
+			return nil, fmt.Errorf("reading the environment: %w", err)
+		}
+		return nil, fmt.Errorf("reading %s: %w", file, err)
 	}
+
+	return &cfg, nil
+}
+
+// Validate checks the config attributes. It must be called once the config
+// is final, e.g. after command-line overrides have been applied, since some
+// attributes are only required by the selected provider.
+func (c *Config) Validate() error {
+	if err := c.validateKeys(nil); err != nil {
+		return err
+	}
+	if c.Hours > c.Days*24 {
+		return fmt.Errorf("hours (%d) exceed the forecast window of %d days", c.Hours, c.Days)
+	}
+	return c.ValidateProviders(c.ProviderChain())
+}
+
+// validateKeys checks the attributes against their validate tags, only the
+// given keys when any are given, and names the offending keys of the config
+// file in the error.
+func (c *Config) validateKeys(keys []string) error {
 	validate := validator.New()
-	if err := validate.Struct(&cfg); err != nil {
-		log.Fatalf("Missing required attributes %v\n", err)
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return field.Tag.Get("mapstructure")
+	})
+	err := validate.Struct(c)
+	var fieldErrors validator.ValidationErrors
+	if err != nil && !errors.As(err, &fieldErrors) {
+		return err
+	}
+
+	var all []problem
+	for _, fe := range fieldErrors {
+		// Config.locations[office].days is locations.office.days.
+		key := strings.TrimPrefix(fe.Namespace(), "Config.")
+		key = strings.NewReplacer("[", ".", "]", "").Replace(key)
+		switch fe.Tag() {
+		case "required":
+			all = append(all, problem{key, "is required"})
+		case "oneof":
+			all = append(all, problem{key, fmt.Sprintf("must be one of %s, not %q", strings.ReplaceAll(fe.Param(), " ", ", "), fmt.Sprint(fe.Value()))})
+		case "min":
+			all = append(all, problem{key, "must be at least " + fe.Param()})
+		default:
+			all = append(all, problem{key, "is invalid"})
+		}
 	}
 
-	return &cfg
+	all = append(all, checkLocation("", c.Latitude, c.Longitude, c.Altitude, c.Timezone, c.Location != "")...)
+	for _, name := range c.LocationNames() {
+		loc := c.Locations[name]
+		all = append(all, checkLocation("locations."+name+".", loc.Latitude, loc.Longitude, loc.Altitude, loc.Timezone, false)...)
+	}
+
+	var messages []string
+	for _, p := range all {
+		if len(keys) == 0 || slices.Contains(keys, p.key) {
+			messages = append(messages, p.key+" "+p.message)
+		}
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(messages, "; "))
+}
+
+// problem is an invalid value of the config file.
+type problem struct {
+	key     string
+	message string
+}
+
+// checkLocation checks the attributes of a location, the top-level one or a
+// saved one, whose keys start with prefix. The coordinates may only be left
+// unset when a place name is given instead.
+func checkLocation(prefix string, lat, lng, alt *float64, timezone string, named bool) []problem {
+	var problems []problem
+	// The errors of the domain checks start with the name of the value.
+	add := func(key string, err error) {
+		problems = append(problems, problem{prefix + key, strings.TrimPrefix(err.Error(), key+" ")})
+	}
+	check := func(key string, value *float64, validate func(float64) error, required bool) {
+		switch {
+		case value != nil:
+			if err := validate(*value); err != nil {
+				add(key, err)
+			}
+		case required && prefix == "":
+			problems = append(problems, problem{key, "is required unless location is set"})
+		case required:
+			problems = append(problems, problem{prefix + key, "is required"})
+		}
+	}
+	check("latitude", lat, domain.ValidateLatitude, !named)
+	check("longitude", lng, domain.ValidateLongitude, !named)
+	check("altitude", alt, domain.ValidateAltitude, false)
+	if timezone != "" {
+		if err := domain.ValidateTimezone(timezone); err != nil {
+			add("timezone", err)
+		}
+	}
+	return problems
+}
+
+// ValidateProviders checks that the credentials required by the given
+// providers are set.
+func (c *Config) ValidateProviders(names []string) error {
+	for _, name := range names {
+		if name == "meteoblue" && !c.hasSecrets() {
+			return fmt.Errorf("meteoblue-api-key and meteoblue-shared-secret, or their -file or -command variants, are required by the meteoblue provider")
+		}
+	}
+	return nil
+}
+
+// ProviderChain returns the providers to ask in order: the providers list
+// when configured, the single provider otherwise.
+func (c *Config) ProviderChain() []string {
+	if len(c.Providers) > 0 {
+		return c.Providers
+	}
+	return []string{c.Provider}
+}
+
+// Coordinates returns the latitude and longitude, which are zero when
+// unset. They are set on a validated config.
+func (c *Config) Coordinates() (lat, lng float64) {
+	if c.Latitude != nil {
+		lat = *c.Latitude
+	}
+	if c.Longitude != nil {
+		lng = *c.Longitude
+	}
+	return lat, lng
+}
+
+// SetCoordinates sets the latitude and longitude, e.g. of a geocoded place.
+func (c *Config) SetCoordinates(lat, lng float64) {
+	c.Latitude = &lat
+	c.Longitude = &lng
+}
+
+// SetProviders replaces the configured providers by a comma-separated
+// list such as "meteoblue,openmeteo".
+func (c *Config) SetProviders(list string) {
+	names := strings.Split(list, ",")
+	for i := range names {
+		names[i] = strings.TrimSpace(names[i])
+	}
+	c.Provider = names[0]
+	c.Providers = nil
+	if len(names) > 1 {
+		c.Providers = names
+	}
+}
+
+// SetColumns sets the columns from a comma-separated list.
+func (c *Config) SetColumns(list string) {
+	c.Columns = nil
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			c.Columns = append(c.Columns, name)
+		}
+	}
+}
+
+// SelectLocation applies the saved location with the given name, replacing
+// the top-level coordinates, timezone and, if set, the provider.
+func (c *Config) SelectLocation(name string) error {
+	// Keys of the config file are case-insensitive.
+	loc, ok := c.Locations[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("unknown location %q, saved locations: %s", name, strings.Join(c.LocationNames(), ", "))
+	}
+
+	c.Latitude = loc.Latitude
+	c.Longitude = loc.Longitude
+	c.Altitude = loc.Altitude
+	c.Location = ""
+	c.Timezone = loc.Timezone
+	if loc.Provider != "" {
+		c.SetProviders(loc.Provider)
+	}
+	return nil
+}
+
+// LocationNames returns the sorted names of the saved locations.
+func (c *Config) LocationNames() []string {
+	names := make([]string, 0, len(c.Locations))
+	for name := range c.Locations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
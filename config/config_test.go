@@ -0,0 +1,206 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfig_Validate_location(t *testing.T) {
+	zero := 0.0
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{
+			name: "equator and prime meridian",
+			cfg:  Config{Latitude: &zero, Longitude: &zero},
+		},
+		{
+			name: "place name instead of coordinates",
+			cfg:  Config{Location: "Accra"},
+		},
+		{
+			name:    "unset coordinates",
+			cfg:     Config{},
+			wantErr: "latitude is required unless location is set; longitude is required unless location is set",
+		},
+		{
+			name:    "latitude out of range",
+			cfg:     Config{Latitude: ptr(91.0), Longitude: &zero},
+			wantErr: "latitude must be between -90 and 90 degrees",
+		},
+		{
+			name: "altitude",
+			cfg:  Config{Latitude: &zero, Longitude: &zero, Altitude: ptr(-28.0)},
+		},
+		{
+			name:    "altitude out of range",
+			cfg:     Config{Latitude: &zero, Longitude: &zero, Altitude: ptr(12000.0)},
+			wantErr: "altitude must be between -500 and 9000 metres",
+		},
+		{
+			name:    "unknown timezone",
+			cfg:     Config{Latitude: &zero, Longitude: &zero, Timezone: "Europe/Atlantis"},
+			wantErr: `timezone "Europe/Atlantis" is unknown`,
+		},
+		{
+			name: "saved location at zero",
+			cfg: Config{Location: "Accra", Locations: map[string]SavedLocation{
+				"greenwich": {Latitude: ptr(51.48), Longitude: &zero, Timezone: "Europe/London"},
+			}},
+		},
+		{
+			name: "saved location without longitude",
+			cfg: Config{Location: "Accra", Locations: map[string]SavedLocation{
+				"quito": {Latitude: ptr(-0.22)},
+			}},
+			wantErr: "locations.quito.longitude is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.Provider = DefaultProvider
+			cfg.Geocoder = DefaultGeocoder
+			cfg.Format = DefaultFormat
+			cfg.Units = DefaultUnits
+			cfg.Columns = DefaultColumns
+			cfg.Days = DefaultDays
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_SelectLocation(t *testing.T) {
+	locations := map[string]SavedLocation{
+		"office": {Latitude: ptr(48.14), Longitude: ptr(11.58), Altitude: ptr(519.0), Timezone: "Europe/Berlin"},
+		"cabin":  {Latitude: ptr(61.5), Longitude: ptr(8.0), Provider: "meteoblue, openmeteo"},
+	}
+	tests := []struct {
+		name          string
+		location      string
+		want          Config
+		wantProviders []string
+		wantErr       string
+	}{
+		{
+			name:     "Coordinates, altitude and timezone of the location",
+			location: "office",
+			want: Config{
+				Latitude: ptr(48.14), Longitude: ptr(11.58), Altitude: ptr(519.0), Timezone: "Europe/Berlin",
+			},
+			wantProviders: []string{"openmeteo"},
+		},
+		{
+			name:     "Names are case-insensitive",
+			location: "Office",
+			want: Config{
+				Latitude: ptr(48.14), Longitude: ptr(11.58), Altitude: ptr(519.0), Timezone: "Europe/Berlin",
+			},
+			wantProviders: []string{"openmeteo"},
+		},
+		{
+			name:     "Provider of the location, altitude and timezone reset",
+			location: "cabin",
+			want: Config{
+				Latitude: ptr(61.5), Longitude: ptr(8.0),
+			},
+			wantProviders: []string{"meteoblue", "openmeteo"},
+		},
+		{
+			name:     "Unknown location",
+			location: "home",
+			wantErr:  `unknown location "home", saved locations: cabin, office`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{
+				Latitude:  ptr(52.52),
+				Longitude: ptr(13.41),
+				Altitude:  ptr(34.0),
+				Location:  "Berlin",
+				Timezone:  "Europe/Paris",
+				Provider:  DefaultProvider,
+				Locations: locations,
+			}
+
+			err := cfg.SelectLocation(tt.location)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("SelectLocation() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SelectLocation() error = %v", err)
+			}
+			got := Config{Latitude: cfg.Latitude, Longitude: cfg.Longitude, Altitude: cfg.Altitude, Location: cfg.Location, Timezone: cfg.Timezone}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SelectLocation() = %+v, want %+v", got, tt.want)
+			}
+			if providers := cfg.ProviderChain(); !reflect.DeepEqual(providers, tt.wantProviders) {
+				t.Errorf("ProviderChain() = %v, want %v", providers, tt.wantProviders)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateProviders(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       Config
+		providers []string
+		wantErr   bool
+	}{
+		{
+			name:      "No credentials needed by openmeteo",
+			providers: []string{"openmeteo"},
+		},
+		{
+			name:      "Missing meteoblue credentials",
+			providers: []string{"openmeteo", "meteoblue"},
+			wantErr:   true,
+		},
+		{
+			name:      "Missing shared secret",
+			cfg:       Config{MeteoblueAPIKey: "key"},
+			providers: []string{"meteoblue"},
+			wantErr:   true,
+		},
+		{
+			name:      "Credentials as values",
+			cfg:       Config{MeteoblueAPIKey: "key", MeteoblueAPISharedSecret: "secret"},
+			providers: []string{"meteoblue"},
+		},
+		{
+			name:      "Credentials from a file and a command",
+			cfg:       Config{MeteoblueAPIKeyFile: "/run/secrets/key", MeteoblueAPISharedSecretCommand: "pass meteoblue"},
+			providers: []string{"meteoblue"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.ValidateProviders(tt.providers)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateProviders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// EnvPrefix starts the names of the environment variables overriding config
+// keys: METEO_PROVIDER for provider, METEO_METEOBLUE_API_KEY for
+// meteoblue-api-key, METEO_COMPARE_THRESHOLDS_TEMPERATURE for
+// compare-thresholds.temperature.
+const EnvPrefix = "METEO"
+
+// bindEnv makes every key of the config, nested ones included, overridable
+// by its environment variable. Viper only looks up variables for keys it
+// knows, so they are bound explicitly.
+func bindEnv(vp *viper.Viper) {
+	vp.SetEnvPrefix(EnvPrefix)
+	vp.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	bindStruct(vp, reflect.TypeOf(Config{}), "")
+}
+
+func bindStruct(vp *viper.Viper, t reflect.Type, prefix string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := field.Tag.Get("mapstructure")
+		if key == "" || key == "-" {
+			continue
+		}
+		if field.Type.Kind() == reflect.Struct {
+			bindStruct(vp, field.Type, prefix+key+".")
+			continue
+		}
+		if field.Type.Kind() == reflect.Map {
+			// Saved locations are only read from the file.
+			continue
+		}
+		_ = vp.BindEnv(prefix + key)
+	}
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadConfig_env(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key")
+	if err := os.WriteFile(keyFile, []byte("file-key\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		file string
+	}{
+		{
+			name: "Values in the file",
+			file: "meteoblue-api-key: yaml-key\nmeteoblue-shared-secret: yaml-secret\n",
+		},
+		{
+			name: "File and command in the file",
+			// The command fails, so it must not run.
+			file: "meteoblue-api-key-file: " + keyFile + "\nmeteoblue-shared-secret-command: exit 1\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.file+"compare-thresholds:\n  temperature: 1\n"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			t.Setenv("METEO_METEOBLUE_API_KEY", "env-key")
+			t.Setenv("METEO_METEOBLUE_SHARED_SECRET", "env-secret")
+			t.Setenv("METEO_COMPARE_THRESHOLDS_TEMPERATURE", "3.5")
+
+			cfg, err := ReadConfig(path)
+			if err != nil {
+				t.Fatalf("ReadConfig() error = %v", err)
+			}
+			if err := cfg.ResolveSecrets(); err != nil {
+				t.Fatalf("ResolveSecrets() error = %v", err)
+			}
+			if cfg.MeteoblueAPIKey != "env-key" || cfg.MeteoblueAPISharedSecret != "env-secret" {
+				t.Errorf("credentials = %q, %q, want the environment", cfg.MeteoblueAPIKey, cfg.MeteoblueAPISharedSecret)
+			}
+			if cfg.CompareThresholds.Temperature != 3.5 {
+				t.Errorf("compare-thresholds.temperature = %v, want 3.5", cfg.CompareThresholds.Temperature)
+			}
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package config
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PathEnv names the environment variable giving the config file, like
+// --config.
+const PathEnv = EnvPrefix + "_CONFIG"
+
+// Example is the commented config file written by meteo config init.
+//
+//go:embed config.yaml.example
+var Example []byte
+
+// SearchPaths returns the config files looked for, in order, when no path
+// is given: config.yaml in the user config directory, e.g.
+// $XDG_CONFIG_HOME/meteo or ~/.config/meteo, then config/config.yaml below
+// the working directory, as in the source tree.
+func SearchPaths() []string {
+	var paths []string
+	if path, err := DefaultPath(); err == nil {
+		paths = append(paths, path)
+	}
+	return append(paths, filepath.Join("config", "config.yaml"))
+}
+
+// DefaultPath returns config.yaml in the user config directory, where meteo
+// config init creates it.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "meteo", "config.yaml"), nil
+}
+
+// FindConfig returns the config file to read: path when given, else the one
+// named by METEO_CONFIG, else the first of SearchPaths which exists. It
+// returns an empty path when there is none, and an error when an explicitly
+// given file does not exist.
+func FindConfig(path string) (string, error) {
+	if path == "" {
+		path = os.Getenv(PathEnv)
+	}
+	if path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("config file: %w", err)
+		}
+		return path, nil
+	}
+
+	for _, candidate := range SearchPaths() {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", nil
+}
+
+// WriteExample creates the config file at path from Example. An existing
+// file is only replaced when force is set.
+func WriteExample(path string, force bool) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !force {
+		flags |= os.O_EXCL
+	}
+	// The file may hold credentials.
+	f, err := os.OpenFile(path, flags, 0o600)
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("%s already exists, use --force to replace it", path)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(Example); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Keys returns the keys of the config file, nested ones joined with dots,
+// e.g. custom-units.temperature. The fields of a saved location are given
+// as locations.<name>.latitude and so on.
+func Keys() []string {
+	return structKeys(reflect.TypeOf(Config{}), "")
+}
+
+func structKeys(t reflect.Type, prefix string) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := field.Tag.Get("mapstructure")
+		switch {
+		case key == "" || key == "-":
+		case field.Type.Kind() == reflect.Struct:
+			keys = append(keys, structKeys(field.Type, prefix+key+".")...)
+		case field.Type.Kind() == reflect.Map:
+			keys = append(keys, structKeys(field.Type.Elem(), prefix+key+".<name>.")...)
+		default:
+			keys = append(keys, prefix+key)
+		}
+	}
+	return keys
+}
+
+// isKey reports whether key names a value of the config file.
+func isKey(key string) bool {
+	parts := strings.Split(key, ".")
+	if len(parts) == 3 && parts[0] == "locations" && parts[1] != "" {
+		parts[1] = "<name>"
+	}
+	return slices.Contains(Keys(), strings.Join(parts, "."))
+}
+
+// SetValue sets key, e.g. latitude or custom-units.temperature, to value in
+// the config file at path, which is created when missing. The value is read
+// as YAML, so "52.52", "true" and "[meteoblue, openmeteo]" keep their type.
+// The file is rewritten from its YAML nodes: the other keys, their order and
+// the comments are preserved, but blank lines are dropped and missing keys
+// are added at the end, not in place of a commented-out example. The file is
+// left unchanged when the result cannot be loaded, e.g. for "days abc".
+func SetValue(path, key, value string) error {
+	key = strings.ToLower(key)
+	if !isKey(key) {
+		return fmt.Errorf("unknown key %q, see meteo config show for the keys", key)
+	}
+
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("reading %s: not a mapping of keys to values", path)
+	}
+
+	var parsed yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &parsed); err != nil {
+		return fmt.Errorf("value of %s: %w", key, err)
+	}
+	node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	if len(parsed.Content) > 0 {
+		node = parsed.Content[0]
+	}
+	if err := setNode(doc.Content[0], strings.Split(key, "."), node); err != nil {
+		return fmt.Errorf("setting %s: %w", key, err)
+	}
+
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+
+	// Write next to the file first, so that a value the config cannot hold
+	// leaves it intact.
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	mode := fs.FileMode(0o600)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".config-*.yaml")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(out.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	cfg, err := ReadConfig(tmp.Name())
+	if err != nil {
+		return fmt.Errorf("value of %s: %w", key, errors.Unwrap(err))
+	}
+	// Other keys may still be missing, e.g. longitude after latitude.
+	if err := cfg.validateKeys([]string{key}); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// setNode sets the value below the path of keys in mapping, adding the
+// missing keys.
+func setNode(mapping *yaml.Node, path []string, value *yaml.Node) error {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if strings.ToLower(mapping.Content[i].Value) != path[0] {
+			continue
+		}
+		if len(path) == 1 {
+			// Keep the comments written next to the old value.
+			value.LineComment = mapping.Content[i+1].LineComment
+			mapping.Content[i+1] = value
+			return nil
+		}
+		child := mapping.Content[i+1]
+		if child.Kind != yaml.MappingNode {
+			if child.Tag != "!!null" {
+				return fmt.Errorf("%s is not a mapping", path[0])
+			}
+			*child = yaml.Node{Kind: yaml.MappingNode}
+		}
+		return setNode(child, path[1:], value)
+	}
+
+	// Add the key at the end.
+	key := &yaml.Node{Kind: yaml.ScalarNode, Value: path[0]}
+	if len(path) == 1 {
+		mapping.Content = append(mapping.Content, key, value)
+		return nil
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	mapping.Content = append(mapping.Content, key, child)
+	return setNode(child, path[1:], value)
+}
+
+// masked replaces a secret in the output of Marshal.
+const masked = "********"
+
+// Marshal returns the config as YAML, in the form of the config file. The
+// meteoblue credentials are masked and unset optional keys omitted.
+func (c *Config) Marshal() ([]byte, error) {
+	shown := *c
+	for _, s := range shown.secrets() {
+		if *s.value != "" {
+			*s.value = masked
+		}
+	}
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(valueNode(reflect.ValueOf(shown))); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// valueNode builds the YAML node of v, naming struct fields by their
+// mapstructure tags.
+func valueNode(v reflect.Value) *yaml.Node {
+	if d, ok := v.Interface().(time.Duration); ok {
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: d.String()}
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		for i := 0; i < v.NumField(); i++ {
+			key := v.Type().Field(i).Tag.Get("mapstructure")
+			field := v.Field(i)
+			if key == "" || key == "-" || (field.Kind() != reflect.Struct && isEmpty(field)) {
+				continue
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, valueNode(field))
+		}
+		return node
+	case reflect.Map:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, key := range keys {
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key.String()}, valueNode(v.MapIndex(key)))
+		}
+		return node
+	case reflect.Slice:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+		for i := 0; i < v.Len(); i++ {
+			node.Content = append(node.Content, valueNode(v.Index(i)))
+		}
+		return node
+	default:
+		var node yaml.Node
+		_ = node.Encode(v.Interface())
+		return &node
+	}
+}
+
+// isEmpty reports whether an optional key is unset. Numbers and booleans
+// are always shown, since their zero value is meaningful.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	case reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
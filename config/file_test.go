@@ -0,0 +1,159 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetValue(t *testing.T) {
+	const original = `# Berlin
+latitude: 52.52 # north
+longitude: 13.405
+`
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "replace keeps comments",
+			key:   "latitude",
+			value: "48.1",
+			want:  []string{"# Berlin", "latitude: 48.1 # north", "longitude: 13.405"},
+		},
+		{
+			name:  "add nested key",
+			key:   "custom-units.temperature",
+			value: "K",
+			want:  []string{"latitude: 52.52 # north", "custom-units:\n  temperature: K"},
+		},
+		{
+			name:  "add saved location",
+			key:   "locations.Office.latitude",
+			value: "1.5",
+			want:  []string{"locations:\n  office:\n    latitude: 1.5"},
+		},
+		{
+			name:  "list",
+			key:   "providers",
+			value: "[meteoblue, openmeteo]",
+			want:  []string{"providers: [meteoblue, openmeteo]"},
+		},
+		{
+			name:    "unknown key",
+			key:     "latitud",
+			value:   "1",
+			wantErr: true,
+		},
+		{
+			name:    "wrong type",
+			key:     "days",
+			value:   "abc",
+			wantErr: true,
+		},
+		{
+			name:    "invalid value",
+			key:     "units",
+			value:   "nautical",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(original), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			err := SetValue(path, tt.key, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantErr {
+				if string(data) != original {
+					t.Errorf("SetValue() changed the file to %q", data)
+				}
+				return
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(data), want) {
+					t.Errorf("SetValue() wrote %q, want it to contain %q", data, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSetValue_layout(t *testing.T) {
+	const original = `# Location
+latitude: 52.52 # north
+longitude: 13.405
+
+#location: "Berlin, DE"
+
+days: 3
+`
+	// Comments and the order of keys are kept, blank lines are not, and a
+	// new key goes to the end.
+	const want = `# Location
+latitude: 52.52 # north
+longitude: 13.405
+#location: "Berlin, DE"
+days: 5
+location: Berlin
+`
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(original), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetValue(path, "location", "Berlin"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetValue(path, "days", "5"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != want {
+		t.Errorf("SetValue() wrote\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestReadConfig(t *testing.T) {
+	t.Setenv(PathEnv, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	// No config/config.yaml below the working directory either.
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	if _, err := ReadConfig(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("ReadConfig() of a missing file succeeded")
+	}
+
+	t.Setenv("METEO_LATITUDE", "52.52")
+	cfg, err := ReadConfig("")
+	if err != nil {
+		t.Fatalf("ReadConfig() without a file error = %v", err)
+	}
+	if cfg.File != "" || cfg.Latitude == nil || *cfg.Latitude != 52.52 || cfg.Days != DefaultDays {
+		t.Errorf("ReadConfig() = %+v, want the defaults and the environment", cfg)
+	}
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// secret is a credential given by its value, e.g. meteoblue-api-key, by a
+// file holding it, meteoblue-api-key-file, as mounted for Docker and
+// Kubernetes secrets, or by a command printing it, meteoblue-api-key-command,
+// e.g. "pass show meteoblue". The first one set is used in this order.
+type secret struct {
+	key     string
+	value   *string
+	file    string
+	command string
+}
+
+func (c *Config) secrets() []secret {
+	return []secret{
+		{key: "meteoblue-api-key", value: &c.MeteoblueAPIKey, file: c.MeteoblueAPIKeyFile, command: c.MeteoblueAPIKeyCommand},
+		{key: "meteoblue-shared-secret", value: &c.MeteoblueAPISharedSecret, file: c.MeteoblueAPISharedSecretFile, command: c.MeteoblueAPISharedSecretCommand},
+	}
+}
+
+// hasSecrets reports whether every credential is given in one of its forms.
+func (c *Config) hasSecrets() bool {
+	for _, s := range c.secrets() {
+		if *s.value == "" && s.file == "" && s.command == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// ResolveSecrets reads the credentials given by a file or a command into
+// their value fields. It is called once a provider needing them is about to
+// be used, so that no command runs for the other providers.
+func (c *Config) ResolveSecrets() error {
+	for _, s := range c.secrets() {
+		if *s.value != "" {
+			continue
+		}
+
+		var value string
+		switch {
+		case s.file != "":
+			data, err := os.ReadFile(s.file)
+			if err != nil {
+				return fmt.Errorf("reading %s-file: %w", s.key, err)
+			}
+			value = strings.TrimSpace(string(data))
+		case s.command != "":
+			out, err := runSecretCommand(s.command)
+			if err != nil {
+				return fmt.Errorf("running %s-command: %w", s.key, err)
+			}
+			value = out
+		default:
+			continue
+		}
+
+		if value == "" {
+			return fmt.Errorf("%s is empty", s.key)
+		}
+		*s.value = value
+	}
+	return nil
+}
+
+// runSecretCommand runs command with the shell and returns the first line
+// of its output. Stdin and stderr stay attached to the terminal, so that
+// password managers can prompt.
+func runSecretCommand(command string) (string, error) {
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	cmd := exec.Command(shell, flag, command)
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	line, _, _ := bufio.NewReader(bytes.NewReader(out)).ReadLine()
+	return strings.TrimSpace(string(line)), nil
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfig_ResolveSecrets(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key")
+	if err := os.WriteFile(keyFile, []byte("file-key\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		cfg        Config
+		wantKey    string
+		wantSecret string
+		wantErr    bool
+	}{
+		{
+			name:       "values",
+			cfg:        Config{MeteoblueAPIKey: "key", MeteoblueAPISharedSecret: "secret"},
+			wantKey:    "key",
+			wantSecret: "secret",
+		},
+		{
+			name:       "file and command",
+			cfg:        Config{MeteoblueAPIKeyFile: keyFile, MeteoblueAPISharedSecretCommand: "printf 'cmd-secret\\nrest'"},
+			wantKey:    "file-key",
+			wantSecret: "cmd-secret",
+		},
+		{
+			name:       "value takes precedence",
+			cfg:        Config{MeteoblueAPIKey: "key", MeteoblueAPIKeyFile: keyFile, MeteoblueAPISharedSecret: "secret"},
+			wantKey:    "key",
+			wantSecret: "secret",
+		},
+		{
+			name:    "missing file",
+			cfg:     Config{MeteoblueAPIKeyFile: filepath.Join(dir, "missing")},
+			wantErr: true,
+		},
+		{
+			name:    "failing command",
+			cfg:     Config{MeteoblueAPIKeyCommand: "exit 1"},
+			wantErr: true,
+		},
+		{
+			name:    "empty output",
+			cfg:     Config{MeteoblueAPIKeyCommand: "true"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			err := cfg.ResolveSecrets()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveSecrets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if cfg.MeteoblueAPIKey != tt.wantKey || cfg.MeteoblueAPISharedSecret != tt.wantSecret {
+				t.Errorf("ResolveSecrets() = %q, %q, want %q, %q", cfg.MeteoblueAPIKey, cfg.MeteoblueAPISharedSecret, tt.wantKey, tt.wantSecret)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package display
+
+import (
+	"fmt"
+
+	"meteo/internal/domain"
+)
+
+// ConditionColumn names the column of the weather condition. The other
+// columns are named after the variable they show.
+const ConditionColumn = "condition"
+
+type column struct {
+	header string
+	format func(v float64, u domain.Units) string
+}
+
+// columns maps the name of every selectable column but the condition to
+// its header and the formatting of its values.
+var columns = map[domain.Variable]column{
+	domain.Temperature:              {header: "Temp", format: formatTemperature},
+	domain.ApparentTemperature:      {header: "Feels", format: formatTemperature},
+	domain.PrecipitationProbability: {header: "Rain", format: formatPercent},
+	domain.Precipitation:            {header: "Precip", format: formatPrecipitation},
+	domain.Humidity:                 {header: "Humidity", format: formatPercent},
+	domain.DewPoint:                 {header: "Dew point", format: formatTemperature},
+	domain.Pressure:                 {header: "Pressure", format: unitFormat("%.0f", domain.Pressure)},
+	domain.CloudCover:               {header: "Clouds", format: formatPercent},
+	domain.WindSpeed:                {header: "Wind", format: formatWindSpeed},
+	domain.WindDirection:            {header: "Dir", format: unitFormat("%.0f", domain.WindDirection)},
+	domain.WindGusts:                {header: "Gusts", format: formatWindSpeed},
+	domain.UVIndex:                  {header: "UV", format: unitFormat("%.1f", domain.UVIndex)},
+	domain.Visibility:               {header: "Visibility", format: unitFormat("%.0f", domain.Visibility)},
+}
+
+// IsColumn reports whether name is a column of the hourly table.
+func IsColumn(name string) bool {
+	if name == ConditionColumn {
+		return true
+	}
+	_, ok := columns[domain.Variable(name)]
+	return ok
+}
+
+// missingValue is shown for gaps and for variables the data does not hold.
+const missingValue = "—"
+
+// formatValue formats v, or returns missingValue for a gap.
+func formatValue(v float64, u domain.Units, format func(float64, domain.Units) string) string {
+	if domain.IsMissing(v) {
+		return missingValue
+	}
+	return format(v, u)
+}
+
+// formatCondition returns the weather condition, or missingValue when the
+// provider reported none.
+func formatCondition(state string) string {
+	if state == "" {
+		return missingValue
+	}
+	return state
+}
+
+func formatPercent(v float64, _ domain.Units) string {
+	return fmt.Sprintf("%.0f%%", v)
+}
+
+func formatPrecipitation(v float64, u domain.Units) string {
+	if u.Precipitation == domain.Inches {
+		return fmt.Sprintf("%.2f%s", v, u.Precipitation.Label())
+	}
+	return fmt.Sprintf("%.1f%s", v, u.Precipitation.Label())
+}
+
+func unitFormat(verb string, variable domain.Variable) func(float64, domain.Units) string {
+	return func(v float64, u domain.Units) string {
+		return fmt.Sprintf(verb+"%s", v, variable.Label(u))
+	}
+}
@@ -0,0 +1,184 @@
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"meteo/internal/domain"
+)
+
+// CompareReport describes a side-by-side comparison of providers and its
+// location.
+type CompareReport struct {
+	Location   domain.Location
+	Thresholds domain.Thresholds
+	Comparison *domain.Comparison
+}
+
+type jsonCompareReport struct {
+	SchemaVersion int               `json:"schema_version"`
+	Location      jsonLocation      `json:"location"`
+	Providers     []string          `json:"providers"`
+	Timezone      string            `json:"timezone"`
+	Units         jsonUnits         `json:"units"`
+	Thresholds    jsonThresholds    `json:"thresholds"`
+	Hourly        []jsonCompareHour `json:"hourly"`
+}
+
+type jsonThresholds struct {
+	Temperature              float64 `json:"temperature"`
+	PrecipitationProbability float64 `json:"precipitation_probability"`
+	WindSpeed                float64 `json:"wind_speed"`
+}
+
+type jsonCompareHour struct {
+	Time      string                     `json:"time"`
+	Providers map[string]jsonCompareItem `json:"providers"`
+	Diverging []string                   `json:"diverging"`
+}
+
+type jsonCompareItem struct {
+	Temperature              jsonNumber `json:"temperature"`
+	PrecipitationProbability jsonNumber `json:"precipitation_probability"`
+	WindSpeed                jsonNumber `json:"wind_speed"`
+	Condition                string     `json:"condition"`
+}
+
+// divergenceMarker is appended to the values of a quantity on which the
+// providers disagree.
+const divergenceMarker = "*"
+
+func compareHeader(members []*domain.WeatherData) []string {
+	header := []string{"Time"}
+	for _, quantity := range []string{"Temp", "Rain", "Wind"} {
+		for _, m := range members {
+			header = append(header, quantity+" "+m.Provider)
+		}
+	}
+	return header
+}
+
+func prepareCompareData(c *domain.Comparison, location *time.Location, maxRows int) [][]string {
+	now := time.Now()
+
+	var data [][]string
+	if len(c.Members) == 0 {
+		return data
+	}
+	for i, ts := range c.Members[0].Time {
+		t := time.Unix(ts, 0).In(location)
+		if t.Before(now) {
+			continue
+		}
+		if maxRows > 0 && len(data) >= maxRows {
+			break
+		}
+
+		d := c.Divergence[i]
+		row := []string{t.Format("15:04")}
+		for _, m := range c.Members {
+			row = append(row, marked(formatValue(m.Temperature[i], m.Units, formatTemperature), d.Temperature))
+		}
+		for _, m := range c.Members {
+			row = append(row, marked(formatValue(m.PrecipitationProbability[i], m.Units, formatPercent), d.PrecipitationProbability))
+		}
+		for _, m := range c.Members {
+			row = append(row, marked(formatValue(m.WindSpeed[i], m.Units, formatWindSpeed), d.WindSpeed))
+		}
+		data = append(data, row)
+	}
+	return data
+}
+
+func marked(value string, diverges bool) string {
+	if diverges {
+		return value + divergenceMarker
+	}
+	return value
+}
+
+// DisplayCompare prints up to maxRows upcoming hours with the values of
+// every provider in adjacent columns, or all of them when maxRows is 0.
+// Values differing by more than the thresholds are marked.
+func DisplayCompare(w io.Writer, r *CompareReport, maxRows int) error {
+	location, err := r.Location.Zone()
+	if err != nil {
+		return err
+	}
+
+	var units domain.Units
+	if len(r.Comparison.Members) > 0 {
+		units = r.Comparison.Members[0].Units
+	}
+	limits := r.Thresholds
+	fmt.Fprintf(w, "%s providers differ by more than %s, %.0f%% or %s\n\n",
+		divergenceMarker, formatTemperature(limits.Temperature, units), limits.PrecipitationProbability, formatWindSpeed(limits.WindSpeed, units))
+
+	table := newTable(w, compareHeader(r.Comparison.Members))
+	table.AppendBulk(prepareCompareData(r.Comparison, location, maxRows))
+	table.Render()
+	return nil
+}
+
+// WriteCompareJSON writes every compared hour as indented JSON, with the
+// values of each provider and the names of the diverging quantities.
+func WriteCompareJSON(w io.Writer, r *CompareReport) error {
+	location, err := r.Location.Zone()
+	if err != nil {
+		return err
+	}
+
+	c := r.Comparison
+	out := jsonCompareReport{
+		SchemaVersion: jsonSchemaVersion,
+		Location:      newJSONLocation(r.Location),
+		Providers:     []string{},
+		Timezone:      r.Location.Timezone,
+		Thresholds:    jsonThresholds(r.Thresholds),
+		Hourly:        []jsonCompareHour{},
+	}
+	for _, m := range c.Members {
+		out.Providers = append(out.Providers, m.Provider)
+	}
+	if len(c.Members) > 0 {
+		out.Units = newJSONUnits(c.Members[0].Units)
+	} else {
+		out.Units = newJSONUnits(domain.Units{})
+	}
+
+	if len(c.Members) > 0 {
+		for i, ts := range c.Members[0].Time {
+			hour := jsonCompareHour{
+				Time:      time.Unix(ts, 0).In(location).Format(time.RFC3339),
+				Providers: make(map[string]jsonCompareItem, len(c.Members)),
+				Diverging: []string{},
+			}
+			for _, m := range c.Members {
+				hour.Providers[m.Provider] = jsonCompareItem{
+					Temperature:              jsonNumber(m.Temperature[i]),
+					PrecipitationProbability: jsonNumber(m.PrecipitationProbability[i]),
+					WindSpeed:                jsonNumber(m.WindSpeed[i]),
+					Condition:                m.WeatherState[i],
+				}
+			}
+
+			d := c.Divergence[i]
+			if d.Temperature {
+				hour.Diverging = append(hour.Diverging, "temperature")
+			}
+			if d.PrecipitationProbability {
+				hour.Diverging = append(hour.Diverging, "precipitation_probability")
+			}
+			if d.WindSpeed {
+				hour.Diverging = append(hour.Diverging, "wind_speed")
+			}
+			out.Hourly = append(out.Hourly, hour)
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
@@ -0,0 +1,93 @@
+package display
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"meteo/internal/domain"
+)
+
+// csvVariables are the variables between the time and the condition.
+var csvVariables = []domain.Variable{domain.Temperature, domain.PrecipitationProbability, domain.WindSpeed}
+
+// WriteCSV writes every hourly record of the report with a header row.
+// The comma is ',' for CSV and '\t' for TSV; times are ISO-8601 in the
+// report's timezone. The column of a variable is named after it and its
+// unit, e.g. temperature_°C. The further variables follow the condition
+// and are left empty when the provider does not deliver them.
+func WriteCSV(w io.Writer, r *Report, comma rune) error {
+	location, err := r.Location.Zone()
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+
+	weather := r.Weather
+	header := []string{"time"}
+	for _, v := range csvVariables {
+		header = append(header, csvColumn(v, weather.Units))
+	}
+	header = append(header, "condition")
+	for _, v := range extraVariables {
+		header = append(header, csvColumn(v, weather.Units))
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for i, ts := range weather.Time {
+		record := []string{
+			time.Unix(ts, 0).In(location).Format(time.RFC3339),
+			formatFloat(weather.Temperature[i]),
+			formatFloat(weather.PrecipitationProbability[i]),
+			formatFloat(weather.WindSpeed[i]),
+			weather.WeatherState[i],
+		}
+		for _, v := range extraVariables {
+			value := ""
+			if series := weather.Series(v); series != nil {
+				value = formatFloat(series[i])
+			}
+			record = append(record, value)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvColumn returns the column name of v, followed by its unit if it has
+// one.
+func csvColumn(v domain.Variable, u domain.Units) string {
+	if label := v.Label(u); label != "" {
+		return string(v) + "_" + label
+	}
+	return string(v)
+}
+
+// formatFloat formats v, leaving the field empty for a gap.
+func formatFloat(v float64) string {
+	if domain.IsMissing(v) {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// extraVariables are the variables beyond csvVariables, in the order of
+// domain.Variables.
+var extraVariables = func() []domain.Variable {
+	var extra []domain.Variable
+	for _, v := range domain.Variables {
+		if v != domain.Temperature && v != domain.PrecipitationProbability && v != domain.WindSpeed {
+			extra = append(extra, v)
+		}
+	}
+	return extra
+}()
@@ -0,0 +1,87 @@
+package display
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"meteo/internal/domain"
+)
+
+// testReport returns a forecast of two hours far in the future, with gaps
+// in the delivered variables and the humidity as the only further one.
+func testReport() *Report {
+	n := domain.Missing()
+	elevation := 34.0
+	return &Report{
+		Location: domain.Location{Name: "Berlin", Latitude: 52.52, Longitude: 13.405, Elevation: &elevation, Timezone: "Europe/Berlin"},
+		Provider: "openmeteo",
+		Weather: &domain.WeatherData{
+			Units:                    domain.Metric,
+			Time:                     []int64{4102444800, 4102448400},
+			Temperature:              []float64{1.5, n},
+			PrecipitationProbability: []float64{n, 20},
+			WindSpeed:                []float64{10, 12.5},
+			WeatherState:             []string{"Fog", ""},
+			Humidity:                 []float64{80, n},
+		},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	tests := []struct {
+		name  string
+		comma rune
+		want  string
+	}{
+		{
+			name:  "CSV",
+			comma: ',',
+			want: `time,temperature_°C,precipitation_probability_%,wind_speed_km/h,condition,apparent_temperature_°C,precipitation_mm,humidity_%,dew_point_°C,pressure_hPa,cloud_cover_%,wind_direction_°,wind_gusts_km/h,uv_index,visibility_m
+2100-01-01T01:00:00+01:00,1.5,,10,Fog,,,80,,,,,,,
+2100-01-01T02:00:00+01:00,,20,12.5,,,,,,,,,,,
+`,
+		},
+		{
+			name:  "TSV",
+			comma: '\t',
+			want: "time\ttemperature_°C\tprecipitation_probability_%\twind_speed_km/h\tcondition\tapparent_temperature_°C\tprecipitation_mm\thumidity_%\tdew_point_°C\tpressure_hPa\tcloud_cover_%\twind_direction_°\twind_gusts_km/h\tuv_index\tvisibility_m\n" +
+				"2100-01-01T01:00:00+01:00\t1.5\t\t10\tFog\t\t\t80\t\t\t\t\t\t\t\n" +
+				"2100-01-01T02:00:00+01:00\t\t20\t12.5\t\t\t\t\t\t\t\t\t\t\t\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteCSV(&buf, testReport(), tt.comma); err != nil {
+				t.Fatalf("WriteCSV() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("WriteCSV() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteCSV_units(t *testing.T) {
+	r := testReport()
+	r.Weather.Units = domain.Imperial
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, r, ','); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	header, _, _ := strings.Cut(buf.String(), "\n")
+	want := "time,temperature_°F,precipitation_probability_%,wind_speed_mph,condition,apparent_temperature_°F,precipitation_in,humidity_%,dew_point_°F,pressure_hPa,cloud_cover_%,wind_direction_°,wind_gusts_mph,uv_index,visibility_m"
+	if header != want {
+		t.Errorf("WriteCSV() header = %q, want %q", header, want)
+	}
+}
+
+func TestWriteCSV_unknownTimezone(t *testing.T) {
+	r := testReport()
+	r.Location.Timezone = "Mars/Olympus_Mons"
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, r, ','); err == nil {
+		t.Error("WriteCSV() with an unknown timezone succeeded")
+	}
+}
@@ -0,0 +1,36 @@
+package display
+
+import (
+	"io"
+	"time"
+
+	"meteo/internal/domain"
+)
+
+func prepareDailyData(weather *domain.WeatherData, location *time.Location) [][]string {
+	var data [][]string
+	for _, day := range domain.Daily(weather, location) {
+		data = append(data, []string{
+			day.Date.Format("Mon 02 Jan"),
+			formatValue(day.MinTemperature, weather.Units, formatTemperature),
+			formatValue(day.MaxTemperature, weather.Units, formatTemperature),
+			formatValue(day.MaxPrecipitationProbability, weather.Units, formatPercent),
+			formatValue(day.MaxWindSpeed, weather.Units, formatWindSpeed),
+			formatCondition(day.Condition),
+		})
+	}
+	return data
+}
+
+// DisplayDaily prints one summary row per day of the whole forecast.
+func DisplayDaily(w io.Writer, r *Report) error {
+	location, err := r.Location.Zone()
+	if err != nil {
+		return err
+	}
+
+	table := newTable(w, []string{"Date", "Min", "Max", "Rain", "Wind", "Condition"})
+	table.AppendBulk(prepareDailyData(r.Weather, location))
+	table.Render()
+	return nil
+}
@@ -0,0 +1,45 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDisplayDaily(t *testing.T) {
+	missingCondition := testReport()
+	missingCondition.Weather.WeatherState = []string{"", ""}
+
+	tests := []struct {
+		name   string
+		report *Report
+		want   string
+	}{
+		{
+			name:   "Most frequent condition",
+			report: testReport(),
+			want: `Date        Min    Max    Rain  Wind      Condition
+----        ---    ---    ----  ----      ---------
+Fri 01 Jan  1.5°C  1.5°C  20%   12.5km/h  Fog
+`,
+		},
+		{
+			name:   "No condition reported",
+			report: missingCondition,
+			want: `Date        Min    Max    Rain  Wind      Condition
+----        ---    ---    ----  ----      ---------
+Fri 01 Jan  1.5°C  1.5°C  20%   12.5km/h  —
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := DisplayDaily(&buf, tt.report); err != nil {
+				t.Fatalf("DisplayDaily() error = %v", err)
+			}
+			if got := trimLines(buf.String()); got != tt.want {
+				t.Errorf("DisplayDaily() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,8 @@
 package display
 
 import (
-	"fmt"
-	"os"
+	"io"
+	"strings"
 	"time"
 
 	"meteo/internal/domain"
@@ -10,9 +10,7 @@ import (
 	"github.com/olekukonko/tablewriter"
 )
 
-const maxRows = 12
-
-func prepareWeatherData(weather *domain.WeatherData, timezone string) [][]string {
+func prepareWeatherData(weather *domain.WeatherData, location *time.Location, maxRows int, names []string) [][]string {
 	currentTime := time.Now()
 
 	var data [][]string
@@ -20,61 +18,77 @@ func prepareWeatherData(weather *domain.WeatherData, timezone string) [][]string
 	i := 0
 	rowsCnt := 0
 	for i < len(weather.Time) {
-		datetime := time.Unix(weather.Time[i], 0)
-		temperature := weather.Temperature[i]
-		weatherState := weather.WeatherState[i]
-		precipitationProbability := weather.PrecipitationProbability[i]
-		windSpeed := weather.WindSpeed[i]
-
-		location, err := time.LoadLocation(timezone)
-		if err != nil {
-			fmt.Println("Error loading timezone:", err)
-			os.Exit(1)
-		}
-		datetimeInLocation := datetime.In(location)
-		if err != nil {
-			fmt.Println("Error parsing datetime:", err)
-			os.Exit(1)
-		}
+		datetimeInLocation := time.Unix(weather.Time[i], 0).In(location)
 
 		if datetimeInLocation.Before(currentTime) {
 			i += 1
 			continue
 		}
-		if rowsCnt > maxRows {
+		if maxRows > 0 && rowsCnt >= maxRows {
 			break
 		}
 
-		hour := datetimeInLocation.Hour()
-		formattedHour := fmt.Sprintf("%02d:00", hour)
-		formattedTemperature := fmt.Sprintf("%.1f°C", temperature)
-		formattedPrecipitationProbability := fmt.Sprintf("%.0f%%", precipitationProbability)
-		formattedWindSpeed := fmt.Sprintf("%.1fkm/h", windSpeed)
+		row := []string{datetimeInLocation.Format("15:04")}
+		for _, name := range names {
+			row = append(row, formatCell(weather, name, i))
+		}
 
-		data = append(data, []string{
-			formattedHour,
-			formattedTemperature,
-			formattedPrecipitationProbability,
-			formattedWindSpeed,
-			weatherState,
-		})
+		data = append(data, row)
 		i += 1
 		rowsCnt += 1
 	}
 	return data
 }
 
-func DisplayTable(weather *domain.WeatherData, timezone string) {
-	data := prepareWeatherData(weather, timezone)
+func formatCell(weather *domain.WeatherData, name string, i int) string {
+	if name == ConditionColumn {
+		return formatCondition(weather.WeatherState[i])
+	}
+	series := weather.Series(domain.Variable(name))
+	if series == nil {
+		return missingValue
+	}
+	return formatValue(series[i], weather.Units, columns[domain.Variable(name)].format)
+}
+
+func tableHeader(names []string) []string {
+	header := []string{"Time"}
+	for _, name := range names {
+		if name == ConditionColumn {
+			header = append(header, "Condition")
+		} else {
+			header = append(header, columns[domain.Variable(name)].header)
+		}
+	}
+	return header
+}
+
+// DisplayTable prints up to maxRows upcoming hours of the forecast, or all of
+// them when maxRows is 0, with the given columns after the time. Column
+// names are checked with IsColumn beforehand.
+func DisplayTable(w io.Writer, r *Report, maxRows int, names []string) error {
+	location, err := r.Location.Zone()
+	if err != nil {
+		return err
+	}
+	data := prepareWeatherData(r.Weather, location, maxRows, names)
 
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{
-		"Time\n----",
-		"Temp\n----",
-		"Rain\n----",
-		"Wind\n----",
-		"Condition\n---------",
-	})
+	table := newTable(w, tableHeader(names))
+	table.AppendBulk(data) // Add Bulk Data
+	table.Render()
+	return nil
+}
+
+// newTable returns a borderless table writing to w, with every header
+// underlined by dashes.
+func newTable(w io.Writer, header []string) *tablewriter.Table {
+	underlined := make([]string, len(header))
+	for i, h := range header {
+		underlined[i] = h + "\n" + strings.Repeat("-", len(h))
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(underlined)
 	table.SetAutoWrapText(false)
 	table.SetAutoFormatHeaders(false)
 	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
@@ -86,6 +100,5 @@ func DisplayTable(weather *domain.WeatherData, timezone string) {
 	table.SetBorder(false)
 	table.SetTablePadding("  ")
 	table.SetNoWhiteSpace(true)
-	table.AppendBulk(data) // Add Bulk Data
-	table.Render()
+	return table
 }
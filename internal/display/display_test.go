@@ -0,0 +1,103 @@
+package display
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// trimLines removes the padding tablewriter leaves at the end of lines.
+func trimLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestDisplayTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []string
+		maxRows int
+		want    string
+	}{
+		{
+			name:    "Default columns",
+			columns: []string{"temperature", "precipitation_probability", "wind_speed", "condition"},
+			want: `Time   Temp   Rain  Wind      Condition
+----   ----   ----  ----      ---------
+01:00  1.5°C  —     10.0km/h  Fog
+02:00  —      20%   12.5km/h  —
+`,
+		},
+		{
+			name:    "Gaps in selected columns in the given order",
+			columns: []string{"condition", "humidity", "temperature", "dew_point"},
+			want: `Time   Condition  Humidity  Temp   Dew point
+----   ---------  --------  ----   ---------
+01:00  Fog        80%       1.5°C  —
+02:00  —          —         —      —
+`,
+		},
+		{
+			name:    "Row limit",
+			columns: []string{"temperature"},
+			maxRows: 1,
+			want: `Time   Temp
+----   ----
+01:00  1.5°C
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := DisplayTable(&buf, testReport(), tt.maxRows, tt.columns); err != nil {
+				t.Fatalf("DisplayTable() error = %v", err)
+			}
+			if got := trimLines(buf.String()); got != tt.want {
+				t.Errorf("DisplayTable() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisplayTable_halfHourOffset(t *testing.T) {
+	r := testReport()
+	r.Location.Timezone = "Asia/Kolkata"
+	var buf bytes.Buffer
+	if err := DisplayTable(&buf, r, 0, []string{"temperature"}); err != nil {
+		t.Fatalf("DisplayTable() error = %v", err)
+	}
+	want := `Time   Temp
+----   ----
+05:30  1.5°C
+06:30  —
+`
+	if got := trimLines(buf.String()); got != want {
+		t.Errorf("DisplayTable() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDisplayTable_unknownTimezone(t *testing.T) {
+	r := testReport()
+	r.Location.Timezone = "Mars/Olympus_Mons"
+	var buf bytes.Buffer
+	if err := DisplayTable(&buf, r, 0, []string{"temperature"}); err == nil {
+		t.Error("DisplayTable() with an unknown timezone succeeded")
+	}
+}
+
+func TestIsColumn(t *testing.T) {
+	for _, name := range []string{"temperature", "dew_point", "condition"} {
+		if !IsColumn(name) {
+			t.Errorf("IsColumn(%q) = false, want true", name)
+		}
+	}
+	for _, name := range []string{"", "Temperature", "time"} {
+		if IsColumn(name) {
+			t.Errorf("IsColumn(%q) = true, want false", name)
+		}
+	}
+}
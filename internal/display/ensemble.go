@@ -0,0 +1,122 @@
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"meteo/internal/domain"
+)
+
+// EnsembleReport describes an ensemble forecast and its location.
+type EnsembleReport struct {
+	Location domain.Location
+	Ensemble *domain.EnsembleData
+}
+
+type jsonEnsembleReport struct {
+	SchemaVersion int                `json:"schema_version"`
+	Location      jsonLocation       `json:"location"`
+	Providers     []string           `json:"providers"`
+	Timezone      string             `json:"timezone"`
+	Units         jsonUnits          `json:"units"`
+	Hourly        []jsonEnsembleHour `json:"hourly"`
+}
+
+type jsonEnsembleHour struct {
+	Time                     string     `json:"time"`
+	Temperature              jsonSpread `json:"temperature"`
+	PrecipitationProbability jsonSpread `json:"precipitation_probability"`
+	WindSpeed                jsonSpread `json:"wind_speed"`
+}
+
+type jsonSpread struct {
+	Mean jsonNumber `json:"mean"`
+	Min  jsonNumber `json:"min"`
+	Max  jsonNumber `json:"max"`
+}
+
+func newJSONSpread(s domain.Spread) jsonSpread {
+	return jsonSpread{Mean: jsonNumber(s.Mean), Min: jsonNumber(s.Min), Max: jsonNumber(s.Max)}
+}
+
+func prepareEnsembleData(ensemble *domain.EnsembleData, location *time.Location, maxRows int) [][]string {
+	now := time.Now()
+
+	var data [][]string
+	for i, ts := range ensemble.Time {
+		t := time.Unix(ts, 0).In(location)
+		if t.Before(now) {
+			continue
+		}
+		if maxRows > 0 && len(data) >= maxRows {
+			break
+		}
+
+		data = append(data, []string{
+			t.Format("15:04"),
+			formatSpread(ensemble.Temperature[i], "%.1f", ensemble.Units.Temperature.Label()),
+			formatSpread(ensemble.PrecipitationProbability[i], "%.0f", "%"),
+			formatSpread(ensemble.WindSpeed[i], windSpeedVerb(ensemble.Units), windSpeedSuffix(ensemble.Units)),
+		})
+	}
+	return data
+}
+
+// formatSpread renders the mean followed by the range, e.g. "7.5°C (6.9–8.1)".
+func formatSpread(s domain.Spread, verb, unit string) string {
+	if domain.IsMissing(s.Mean) {
+		return missingValue
+	}
+	return fmt.Sprintf(verb+"%s ("+verb+"–"+verb+")", s.Mean, unit, s.Min, s.Max)
+}
+
+// DisplayEnsemble prints up to maxRows upcoming hours of the ensemble mean
+// and spread, or all of them when maxRows is 0.
+func DisplayEnsemble(w io.Writer, r *EnsembleReport, maxRows int) error {
+	location, err := r.Location.Zone()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Ensemble of %s, mean (min–max)\n\n", strings.Join(r.Ensemble.Providers, ", "))
+
+	table := newTable(w, []string{"Time", "Temp", "Rain", "Wind"})
+	table.AppendBulk(prepareEnsembleData(r.Ensemble, location, maxRows))
+	table.Render()
+	return nil
+}
+
+// WriteEnsembleJSON writes every hour of the ensemble as indented JSON,
+// following the schema of WriteJSON with a spread in place of each value.
+func WriteEnsembleJSON(w io.Writer, r *EnsembleReport) error {
+	location, err := r.Location.Zone()
+	if err != nil {
+		return err
+	}
+
+	ensemble := r.Ensemble
+	out := jsonEnsembleReport{
+		SchemaVersion: jsonSchemaVersion,
+		Location:      newJSONLocation(r.Location),
+		Providers:     ensemble.Providers,
+		Timezone:      r.Location.Timezone,
+		Units:         newJSONUnits(ensemble.Units),
+		Hourly:        make([]jsonEnsembleHour, len(ensemble.Time)),
+	}
+
+	for i, ts := range ensemble.Time {
+		out.Hourly[i] = jsonEnsembleHour{
+			Time:                     time.Unix(ts, 0).In(location).Format(time.RFC3339),
+			Temperature:              newJSONSpread(ensemble.Temperature[i]),
+			PrecipitationProbability: newJSONSpread(ensemble.PrecipitationProbability[i]),
+			WindSpeed:                newJSONSpread(ensemble.WindSpeed[i]),
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
@@ -0,0 +1,213 @@
+package display
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"meteo/internal/domain"
+)
+
+// jsonSchemaVersion is bumped on every incompatible change of the JSON
+// output, see the "JSON output" section of the README.
+const jsonSchemaVersion = 2
+
+// Report describes a forecast together with the context needed to render it
+// outside of the terminal table.
+type Report struct {
+	Location domain.Location
+	Provider string
+	Weather  *domain.WeatherData
+}
+
+type jsonReport struct {
+	SchemaVersion int          `json:"schema_version"`
+	Location      jsonLocation `json:"location"`
+	Provider      string       `json:"provider"`
+	Timezone      string       `json:"timezone"`
+	Units         jsonUnits    `json:"units"`
+	Offline       *jsonOffline `json:"offline,omitempty"`
+	Hourly        []jsonHour   `json:"hourly"`
+	Daily         []jsonDay    `json:"daily,omitempty"`
+}
+
+type jsonLocation struct {
+	Name      string   `json:"name,omitempty"`
+	Latitude  float64  `json:"latitude"`
+	Longitude float64  `json:"longitude"`
+	Elevation *float64 `json:"elevation,omitempty"`
+}
+
+func newJSONLocation(l domain.Location) jsonLocation {
+	return jsonLocation{
+		Name:      l.Name,
+		Latitude:  l.Latitude,
+		Longitude: l.Longitude,
+		Elevation: l.Elevation,
+	}
+}
+
+type jsonOffline struct {
+	FetchedAt  string `json:"fetched_at"`
+	AgeSeconds int64  `json:"age_seconds"`
+}
+
+type jsonUnits struct {
+	Temperature              string `json:"temperature"`
+	PrecipitationProbability string `json:"precipitation_probability"`
+	WindSpeed                string `json:"wind_speed"`
+
+	// Units of the further variables, set for those the provider delivers.
+	ApparentTemperature string `json:"apparent_temperature,omitempty"`
+	Precipitation       string `json:"precipitation,omitempty"`
+	Humidity            string `json:"humidity,omitempty"`
+	DewPoint            string `json:"dew_point,omitempty"`
+	Pressure            string `json:"pressure,omitempty"`
+	CloudCover          string `json:"cloud_cover,omitempty"`
+	WindDirection       string `json:"wind_direction,omitempty"`
+	WindGusts           string `json:"wind_gusts,omitempty"`
+	Visibility          string `json:"visibility,omitempty"`
+}
+
+func newJSONUnits(u domain.Units) jsonUnits {
+	return jsonUnits{
+		Temperature:              u.Temperature.Label(),
+		PrecipitationProbability: "%",
+		WindSpeed:                u.WindSpeed.Label(),
+	}
+}
+
+// withVariables adds the units of the further variables the weather data
+// holds.
+func (ju jsonUnits) withVariables(weather *domain.WeatherData) jsonUnits {
+	label := func(v domain.Variable) string {
+		if !weather.Has(v) {
+			return ""
+		}
+		return v.Label(weather.Units)
+	}
+	ju.ApparentTemperature = label(domain.ApparentTemperature)
+	ju.Precipitation = label(domain.Precipitation)
+	ju.Humidity = label(domain.Humidity)
+	ju.DewPoint = label(domain.DewPoint)
+	ju.Pressure = label(domain.Pressure)
+	ju.CloudCover = label(domain.CloudCover)
+	ju.WindDirection = label(domain.WindDirection)
+	ju.WindGusts = label(domain.WindGusts)
+	ju.Visibility = label(domain.Visibility)
+	return ju
+}
+
+// valueAt returns the i-th value of the variable, nil when the provider
+// does not deliver it.
+func valueAt(weather *domain.WeatherData, v domain.Variable, i int) *jsonNumber {
+	series := weather.Series(v)
+	if series == nil {
+		return nil
+	}
+	n := jsonNumber(series[i])
+	return &n
+}
+
+// jsonNumber is a forecast value, encoded as null when it is Missing.
+type jsonNumber float64
+
+func (n jsonNumber) MarshalJSON() ([]byte, error) {
+	if domain.IsMissing(float64(n)) {
+		return []byte("null"), nil
+	}
+	return json.Marshal(float64(n))
+}
+
+type jsonHour struct {
+	Time                     string     `json:"time"`
+	Temperature              jsonNumber `json:"temperature"`
+	PrecipitationProbability jsonNumber `json:"precipitation_probability"`
+	WindSpeed                jsonNumber `json:"wind_speed"`
+	Condition                string     `json:"condition"`
+
+	// Further variables, omitted when the provider does not deliver them.
+	ApparentTemperature *jsonNumber `json:"apparent_temperature,omitempty"`
+	Precipitation       *jsonNumber `json:"precipitation,omitempty"`
+	Humidity            *jsonNumber `json:"humidity,omitempty"`
+	DewPoint            *jsonNumber `json:"dew_point,omitempty"`
+	Pressure            *jsonNumber `json:"pressure,omitempty"`
+	CloudCover          *jsonNumber `json:"cloud_cover,omitempty"`
+	WindDirection       *jsonNumber `json:"wind_direction,omitempty"`
+	WindGusts           *jsonNumber `json:"wind_gusts,omitempty"`
+	UVIndex             *jsonNumber `json:"uv_index,omitempty"`
+	Visibility          *jsonNumber `json:"visibility,omitempty"`
+}
+
+type jsonDay struct {
+	Date                        string     `json:"date"`
+	MinTemperature              jsonNumber `json:"min_temperature"`
+	MaxTemperature              jsonNumber `json:"max_temperature"`
+	MaxPrecipitationProbability jsonNumber `json:"max_precipitation_probability"`
+	MaxWindSpeed                jsonNumber `json:"max_wind_speed"`
+	Condition                   string     `json:"condition"`
+}
+
+// WriteJSON writes every hourly record of the report as indented JSON, with
+// times in ISO-8601 format in the report's timezone. The daily summaries
+// are included when daily is set.
+func WriteJSON(w io.Writer, r *Report, daily bool) error {
+	location, err := r.Location.Zone()
+	if err != nil {
+		return err
+	}
+
+	weather := r.Weather
+	out := jsonReport{
+		SchemaVersion: jsonSchemaVersion,
+		Location:      newJSONLocation(r.Location),
+		Provider:      r.Provider,
+		Timezone:      r.Location.Timezone,
+		Units:         newJSONUnits(weather.Units).withVariables(weather),
+		Hourly:        make([]jsonHour, len(weather.Time)),
+	}
+
+	if weather.Stale {
+		out.Offline = &jsonOffline{
+			FetchedAt:  weather.FetchedAt.In(location).Format(time.RFC3339),
+			AgeSeconds: int64(time.Since(weather.FetchedAt).Seconds()),
+		}
+	}
+
+	for i, ts := range weather.Time {
+		out.Hourly[i] = jsonHour{
+			Time:                     time.Unix(ts, 0).In(location).Format(time.RFC3339),
+			Temperature:              jsonNumber(weather.Temperature[i]),
+			PrecipitationProbability: jsonNumber(weather.PrecipitationProbability[i]),
+			WindSpeed:                jsonNumber(weather.WindSpeed[i]),
+			Condition:                weather.WeatherState[i],
+			ApparentTemperature:      valueAt(weather, domain.ApparentTemperature, i),
+			Precipitation:            valueAt(weather, domain.Precipitation, i),
+			Humidity:                 valueAt(weather, domain.Humidity, i),
+			DewPoint:                 valueAt(weather, domain.DewPoint, i),
+			Pressure:                 valueAt(weather, domain.Pressure, i),
+			CloudCover:               valueAt(weather, domain.CloudCover, i),
+			WindDirection:            valueAt(weather, domain.WindDirection, i),
+			WindGusts:                valueAt(weather, domain.WindGusts, i),
+			UVIndex:                  valueAt(weather, domain.UVIndex, i),
+			Visibility:               valueAt(weather, domain.Visibility, i),
+		}
+	}
+
+	if daily {
+		for _, day := range domain.Daily(weather, location) {
+			out.Daily = append(out.Daily, jsonDay{
+				Date:                        day.Date.Format(time.DateOnly),
+				MinTemperature:              jsonNumber(day.MinTemperature),
+				MaxTemperature:              jsonNumber(day.MaxTemperature),
+				MaxPrecipitationProbability: jsonNumber(day.MaxPrecipitationProbability),
+				MaxWindSpeed:                jsonNumber(day.MaxWindSpeed),
+				Condition:                   day.Condition,
+			})
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
@@ -0,0 +1,115 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		daily bool
+		want  string
+	}{
+		{
+			name: "Hourly",
+			want: `{
+  "schema_version": 2,
+  "location": {
+    "name": "Berlin",
+    "latitude": 52.52,
+    "longitude": 13.405,
+    "elevation": 34
+  },
+  "provider": "openmeteo",
+  "timezone": "Europe/Berlin",
+  "units": {
+    "temperature": "°C",
+    "precipitation_probability": "%",
+    "wind_speed": "km/h",
+    "humidity": "%"
+  },
+  "hourly": [
+    {
+      "time": "2100-01-01T01:00:00+01:00",
+      "temperature": 1.5,
+      "precipitation_probability": null,
+      "wind_speed": 10,
+      "condition": "Fog",
+      "humidity": 80
+    },
+    {
+      "time": "2100-01-01T02:00:00+01:00",
+      "temperature": null,
+      "precipitation_probability": 20,
+      "wind_speed": 12.5,
+      "condition": "",
+      "humidity": null
+    }
+  ]
+}
+`,
+		},
+		{
+			name:  "With daily summaries",
+			daily: true,
+			want: `{
+  "schema_version": 2,
+  "location": {
+    "name": "Berlin",
+    "latitude": 52.52,
+    "longitude": 13.405,
+    "elevation": 34
+  },
+  "provider": "openmeteo",
+  "timezone": "Europe/Berlin",
+  "units": {
+    "temperature": "°C",
+    "precipitation_probability": "%",
+    "wind_speed": "km/h",
+    "humidity": "%"
+  },
+  "hourly": [
+    {
+      "time": "2100-01-01T01:00:00+01:00",
+      "temperature": 1.5,
+      "precipitation_probability": null,
+      "wind_speed": 10,
+      "condition": "Fog",
+      "humidity": 80
+    },
+    {
+      "time": "2100-01-01T02:00:00+01:00",
+      "temperature": null,
+      "precipitation_probability": 20,
+      "wind_speed": 12.5,
+      "condition": "",
+      "humidity": null
+    }
+  ],
+  "daily": [
+    {
+      "date": "2100-01-01",
+      "min_temperature": 1.5,
+      "max_temperature": 1.5,
+      "max_precipitation_probability": 20,
+      "max_wind_speed": 12.5,
+      "condition": "Fog"
+    }
+  ]
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteJSON(&buf, testReport(), tt.daily); err != nil {
+				t.Fatalf("WriteJSON() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("WriteJSON() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package display
+
+import (
+	"fmt"
+	"time"
+
+	"meteo/internal/domain"
+)
+
+// StaleNotice labels a forecast served from the cache because the provider
+// could not be reached. It is empty for fresh data.
+func StaleNotice(weather *domain.WeatherData, now time.Time) string {
+	if !weather.Stale {
+		return ""
+	}
+	return fmt.Sprintf("Offline: provider unreachable, showing the forecast fetched %s ago", formatAge(now.Sub(weather.FetchedAt)))
+}
+
+// formatAge rounds d to minutes, e.g. "2h15m" or "5m".
+func formatAge(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < time.Minute {
+		return "less than a minute"
+	}
+	s := d.String()
+	return s[:len(s)-2] // Drop the "0s" suffix.
+}
@@ -0,0 +1,31 @@
+package display
+
+import (
+	"fmt"
+
+	"meteo/internal/domain"
+)
+
+func formatTemperature(v float64, u domain.Units) string {
+	return fmt.Sprintf("%.1f%s", v, u.Temperature.Label())
+}
+
+func formatWindSpeed(v float64, u domain.Units) string {
+	return fmt.Sprintf(windSpeedVerb(u)+"%s", v, windSpeedSuffix(u))
+}
+
+// windSpeedVerb prints Beaufort forces as whole numbers, other units with
+// one decimal.
+func windSpeedVerb(u domain.Units) string {
+	if u.WindSpeed == domain.Beaufort {
+		return "%.0f"
+	}
+	return "%.1f"
+}
+
+func windSpeedSuffix(u domain.Units) string {
+	if u.WindSpeed == domain.Beaufort {
+		return " " + u.WindSpeed.Label()
+	}
+	return u.WindSpeed.Label()
+}
@@ -1,9 +1,43 @@
 package domain
 
+import "time"
+
+// WeatherData holds an hourly forecast. Providers deliver it in metric
+// units, Convert switches it to other ones. Values the provider left out are
+// Missing.
 type WeatherData struct {
 	Time                     []int64
-	Temperature              []float64
-	PrecipitationProbability []float64
+	Temperature              Series
+	PrecipitationProbability Series
 	WeatherState             []string
-	WindSpeed                []float64
+	WindSpeed                Series
+
+	// Further variables, see Variable. A nil slice means the provider does
+	// not deliver the variable.
+	ApparentTemperature Series
+	Precipitation       Series
+	Humidity            Series
+	DewPoint            Series
+	Pressure            Series
+	CloudCover          Series
+	WindDirection       Series
+	WindGusts           Series
+	UVIndex             Series
+	Visibility          Series
+
+	// Units are the units of the values, the zero value is metric.
+	Units Units
+
+	// Provider names the provider which delivered the data when it was
+	// chosen among several.
+	Provider string
+
+	// Timezone is the IANA timezone of the location as reported by the
+	// provider, empty when it reports none.
+	Timezone string
+
+	// Stale is set when the provider could not be reached and the data is
+	// an older forecast, fetched at FetchedAt, served from the cache.
+	Stale     bool
+	FetchedAt time.Time
 }
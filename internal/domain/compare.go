@@ -0,0 +1,66 @@
+package domain
+
+// Thresholds are the largest differences between providers that still
+// count as agreement.
+type Thresholds struct {
+	Temperature              float64
+	PrecipitationProbability float64
+	WindSpeed                float64
+}
+
+// Convert returns the thresholds, given in the units the providers deliver,
+// in u. They are differences, so a temperature is scaled without the offset
+// of the unit, and a wind speed becomes the Beaufort force it spans from
+// calm.
+func (t Thresholds) Convert(u Units) Thresholds {
+	return Thresholds{
+		Temperature:              round(ConvertTemperature(t.Temperature, u.Temperature) - ConvertTemperature(0, u.Temperature)),
+		PrecipitationProbability: t.PrecipitationProbability,
+		WindSpeed:                round(ConvertWindSpeed(t.WindSpeed, u.WindSpeed) - ConvertWindSpeed(0, u.WindSpeed)),
+	}
+}
+
+// Divergence tells which quantities of an hour differ between providers by
+// more than the thresholds.
+type Divergence struct {
+	Temperature              bool
+	PrecipitationProbability bool
+	WindSpeed                bool
+}
+
+// Any reports whether any quantity diverges.
+func (d Divergence) Any() bool {
+	return d.Temperature || d.PrecipitationProbability || d.WindSpeed
+}
+
+type Comparison struct {
+	// Members hold the forecasts of the providers, aligned on the hours
+	// they have in common.
+	Members    []*WeatherData
+	Divergence []Divergence
+}
+
+// Compare aligns the forecasts of several providers and flags the hours
+// where they disagree by more than the thresholds. Gaps are left out of the
+// comparison.
+func Compare(members []*WeatherData, limits Thresholds) *Comparison {
+	c := &Comparison{
+		Members: Align(members),
+	}
+	if len(c.Members) == 0 {
+		return c
+	}
+
+	for i := range c.Members[0].Time {
+		temperature := spread(c.Members, i, func(w *WeatherData) []float64 { return w.Temperature })
+		precipitation := spread(c.Members, i, func(w *WeatherData) []float64 { return w.PrecipitationProbability })
+		wind := spread(c.Members, i, func(w *WeatherData) []float64 { return w.WindSpeed })
+
+		c.Divergence = append(c.Divergence, Divergence{
+			Temperature:              temperature.Max-temperature.Min > limits.Temperature,
+			PrecipitationProbability: precipitation.Max-precipitation.Min > limits.PrecipitationProbability,
+			WindSpeed:                wind.Max-wind.Min > limits.WindSpeed,
+		})
+	}
+	return c
+}
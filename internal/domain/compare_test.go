@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	limits := Thresholds{Temperature: 2, PrecipitationProbability: 20, WindSpeed: 5}
+	tests := []struct {
+		name    string
+		members []*WeatherData
+		want    []Divergence
+	}{
+		{
+			name: "Differences within the thresholds",
+			members: []*WeatherData{
+				{Time: []int64{3600}, Temperature: []float64{5}, PrecipitationProbability: []float64{10}, WeatherState: []string{"Fog"}, WindSpeed: []float64{10}},
+				{Time: []int64{3600}, Temperature: []float64{7}, PrecipitationProbability: []float64{30}, WeatherState: []string{"Fog"}, WindSpeed: []float64{15}},
+			},
+			want: []Divergence{{}},
+		},
+		{
+			name: "Differences beyond the thresholds",
+			members: []*WeatherData{
+				{Time: []int64{3600, 7200}, Temperature: []float64{5, 5}, PrecipitationProbability: []float64{10, 10}, WeatherState: []string{"Fog", "Fog"}, WindSpeed: []float64{10, 10}},
+				{Time: []int64{3600, 7200}, Temperature: []float64{7.5, 5}, PrecipitationProbability: []float64{10, 40}, WeatherState: []string{"Fog", "Fog"}, WindSpeed: []float64{10, 20}},
+			},
+			want: []Divergence{
+				{Temperature: true},
+				{PrecipitationProbability: true, WindSpeed: true},
+			},
+		},
+		{
+			name: "Only common hours are compared",
+			members: []*WeatherData{
+				{Time: []int64{3600, 7200}, Temperature: []float64{0, 5}, PrecipitationProbability: []float64{0, 0}, WeatherState: []string{"", ""}, WindSpeed: []float64{0, 0}},
+				{Time: []int64{7200}, Temperature: []float64{5}, PrecipitationProbability: []float64{0}, WeatherState: []string{""}, WindSpeed: []float64{0}},
+			},
+			want: []Divergence{{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Compare(tt.members, limits)
+			if !reflect.DeepEqual(got.Divergence, tt.want) {
+				t.Errorf("Compare() divergence = %+v, want %+v", got.Divergence, tt.want)
+			}
+			for _, m := range got.Members {
+				if len(m.Time) != len(tt.want) {
+					t.Errorf("Compare() member has %d hours, want %d", len(m.Time), len(tt.want))
+				}
+			}
+		})
+	}
+}
+
+func TestThresholdsConvert(t *testing.T) {
+	metric := Thresholds{Temperature: 2, PrecipitationProbability: 20, WindSpeed: 5}
+	tests := []struct {
+		name  string
+		units Units
+		want  Thresholds
+	}{
+		{name: "Metric", units: Metric, want: metric},
+		{name: "Unset units", units: Units{}, want: metric},
+		{name: "Imperial", units: Imperial, want: Thresholds{Temperature: 3.6, PrecipitationProbability: 20, WindSpeed: 3.11}},
+		{name: "Kelvin and Beaufort", units: Units{Temperature: Kelvin, WindSpeed: Beaufort}, want: Thresholds{Temperature: 2, PrecipitationProbability: 20, WindSpeed: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := metric.Convert(tt.units); got != tt.want {
+				t.Errorf("Thresholds.Convert() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
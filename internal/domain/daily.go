@@ -0,0 +1,76 @@
+package domain
+
+import "time"
+
+type DailySummary struct {
+	Date                        time.Time
+	MinTemperature              float64
+	MaxTemperature              float64
+	MaxPrecipitationProbability float64
+	MaxWindSpeed                float64
+	Condition                   string
+}
+
+// Daily aggregates the hourly weather into one summary per calendar day in
+// the given location. The condition of a day is its most frequent weather
+// state; ties go to the state seen first. Gaps are skipped, a value is
+// Missing only when the whole day is.
+func Daily(weather *WeatherData, loc *time.Location) []DailySummary {
+	var days []DailySummary
+	var counts map[string]int
+
+	for i, ts := range weather.Time {
+		t := time.Unix(ts, 0).In(loc)
+		date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+
+		if len(days) == 0 || !days[len(days)-1].Date.Equal(date) {
+			days = append(days, DailySummary{
+				Date:                        date,
+				MinTemperature:              weather.Temperature[i],
+				MaxTemperature:              weather.Temperature[i],
+				MaxPrecipitationProbability: weather.PrecipitationProbability[i],
+				MaxWindSpeed:                weather.WindSpeed[i],
+			})
+			counts = map[string]int{}
+		}
+
+		day := &days[len(days)-1]
+		day.MinTemperature = minOf(day.MinTemperature, weather.Temperature[i])
+		day.MaxTemperature = maxOf(day.MaxTemperature, weather.Temperature[i])
+		day.MaxPrecipitationProbability = maxOf(day.MaxPrecipitationProbability, weather.PrecipitationProbability[i])
+		day.MaxWindSpeed = maxOf(day.MaxWindSpeed, weather.WindSpeed[i])
+
+		state := weather.WeatherState[i]
+		if state == "" {
+			continue
+		}
+		counts[state]++
+		if counts[state] > counts[day.Condition] {
+			day.Condition = state
+		}
+	}
+
+	return days
+}
+
+// minOf returns the smaller value, or the other one when one is Missing.
+func minOf(a, b float64) float64 {
+	if IsMissing(a) {
+		return b
+	}
+	if IsMissing(b) {
+		return a
+	}
+	return min(a, b)
+}
+
+// maxOf returns the larger value, or the other one when one is Missing.
+func maxOf(a, b float64) float64 {
+	if IsMissing(a) {
+		return b
+	}
+	if IsMissing(b) {
+		return a
+	}
+	return max(a, b)
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDaily(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		weather *WeatherData
+		loc     *time.Location
+		want    []DailySummary
+	}{
+		{
+			name: "Hours split at local midnight",
+			weather: &WeatherData{
+				// 2021-01-01 21:00, 22:00, 23:00 UTC.
+				Time:                     []int64{1609534800, 1609538400, 1609542000},
+				Temperature:              []float64{1.5, -0.5, 2.0},
+				PrecipitationProbability: []float64{10, 30, 0},
+				WeatherState:             []string{"Overcast", "Slight snow", "Clear sky"},
+				WindSpeed:                []float64{12, 8, 4},
+			},
+			loc: berlin,
+			want: []DailySummary{
+				{
+					Date:                        time.Date(2021, 1, 1, 0, 0, 0, 0, berlin),
+					MinTemperature:              -0.5,
+					MaxTemperature:              1.5,
+					MaxPrecipitationProbability: 30,
+					MaxWindSpeed:                12,
+					Condition:                   "Overcast",
+				},
+				{
+					Date:                        time.Date(2021, 1, 2, 0, 0, 0, 0, berlin),
+					MinTemperature:              2.0,
+					MaxTemperature:              2.0,
+					MaxPrecipitationProbability: 0,
+					MaxWindSpeed:                4,
+					Condition:                   "Clear sky",
+				},
+			},
+		},
+		{
+			name: "Gaps are skipped",
+			weather: &WeatherData{
+				Time:                     []int64{1609459200, 1609462800, 1609466400},
+				Temperature:              []float64{Missing(), 3, 1},
+				PrecipitationProbability: []float64{20, Missing(), 10},
+				WeatherState:             []string{"Fog", "Fog", "Fog"},
+				WindSpeed:                []float64{5, 7, Missing()},
+			},
+			loc: time.UTC,
+			want: []DailySummary{
+				{
+					Date:                        time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					MinTemperature:              1,
+					MaxTemperature:              3,
+					MaxPrecipitationProbability: 20,
+					MaxWindSpeed:                7,
+					Condition:                   "Fog",
+				},
+			},
+		},
+		{
+			name: "Most frequent condition wins",
+			weather: &WeatherData{
+				Time:                     []int64{1609459200, 1609462800, 1609466400, 1609470000},
+				Temperature:              []float64{1, 2, 3, 4},
+				PrecipitationProbability: []float64{0, 0, 0, 0},
+				WeatherState:             []string{"Fog", "", "Overcast", "Overcast"},
+				WindSpeed:                []float64{1, 1, 1, 1},
+			},
+			loc: time.UTC,
+			want: []DailySummary{
+				{
+					Date:                        time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					MinTemperature:              1,
+					MaxTemperature:              4,
+					MaxPrecipitationProbability: 0,
+					MaxWindSpeed:                1,
+					Condition:                   "Overcast",
+				},
+			},
+		},
+		{
+			name:    "No data",
+			weather: &WeatherData{},
+			loc:     time.UTC,
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Daily(tt.weather, tt.loc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Daily() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
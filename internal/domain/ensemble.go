@@ -0,0 +1,110 @@
+package domain
+
+// Spread summarises the values several providers forecast for one hour.
+type Spread struct {
+	Mean float64
+	Min  float64
+	Max  float64
+}
+
+type EnsembleData struct {
+	Providers                []string
+	Time                     []int64
+	Temperature              []Spread
+	PrecipitationProbability []Spread
+	WindSpeed                []Spread
+	Units                    Units
+}
+
+// Align restricts the members to the hours present in all of them, in the
+// order of the first member, so that index i refers to the same hour in
+// every returned member.
+func Align(members []*WeatherData) []*WeatherData {
+	if len(members) == 0 {
+		return nil
+	}
+
+	counts := map[int64]int{}
+	for _, m := range members {
+		for _, ts := range m.Time {
+			counts[ts]++
+		}
+	}
+	var common []int64
+	for _, ts := range members[0].Time {
+		if counts[ts] == len(members) {
+			common = append(common, ts)
+		}
+	}
+
+	aligned := make([]*WeatherData, len(members))
+	for i, m := range members {
+		index := make(map[int64]int, len(m.Time))
+		for j, ts := range m.Time {
+			index[ts] = j
+		}
+
+		a := &WeatherData{
+			Units:     m.Units,
+			Provider:  m.Provider,
+			Stale:     m.Stale,
+			FetchedAt: m.FetchedAt,
+		}
+		for _, ts := range common {
+			j := index[ts]
+			a.Time = append(a.Time, ts)
+			a.WeatherState = append(a.WeatherState, m.WeatherState[j])
+			for _, v := range Variables {
+				if series := m.Series(v); series != nil {
+					*a.series(v) = append(*a.series(v), series[j])
+				}
+			}
+		}
+		aligned[i] = a
+	}
+	return aligned
+}
+
+// NewEnsemble aligns the forecasts of the named providers and computes the
+// mean and the min/max spread per hour.
+func NewEnsemble(providers []string, members []*WeatherData) *EnsembleData {
+	aligned := Align(members)
+	e := &EnsembleData{
+		Providers: providers,
+	}
+	if len(aligned) == 0 {
+		return e
+	}
+	e.Units = aligned[0].Units
+
+	for i, ts := range aligned[0].Time {
+		e.Time = append(e.Time, ts)
+		e.Temperature = append(e.Temperature, spread(aligned, i, func(w *WeatherData) []float64 { return w.Temperature }))
+		e.PrecipitationProbability = append(e.PrecipitationProbability, spread(aligned, i, func(w *WeatherData) []float64 { return w.PrecipitationProbability }))
+		e.WindSpeed = append(e.WindSpeed, spread(aligned, i, func(w *WeatherData) []float64 { return w.WindSpeed }))
+	}
+	return e
+}
+
+// spread summarises the i-th values of the members, skipping gaps. It is
+// all Missing when every member has a gap.
+func spread(members []*WeatherData, i int, series func(*WeatherData) []float64) Spread {
+	s := Spread{Mean: Missing(), Min: Missing(), Max: Missing()}
+
+	var sum float64
+	var n int
+	for _, m := range members {
+		v := series(m)[i]
+		if IsMissing(v) {
+			continue
+		}
+		sum += v
+		n++
+		s.Min = minOf(s.Min, v)
+		s.Max = maxOf(s.Max, v)
+	}
+	if n > 0 {
+		s.Mean = sum / float64(n)
+	}
+	return s
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewEnsemble(t *testing.T) {
+	tests := []struct {
+		name    string
+		members []*WeatherData
+		want    *EnsembleData
+	}{
+		{
+			name: "Time axes are aligned",
+			members: []*WeatherData{
+				{
+					Time:                     []int64{3600, 7200, 10800},
+					Temperature:              []float64{1, 2, 3},
+					PrecipitationProbability: []float64{10, 20, 30},
+					WeatherState:             []string{"Fog", "Fog", "Fog"},
+					WindSpeed:                []float64{5, 5, 5},
+				},
+				{
+					Time:                     []int64{7200, 10800, 14400},
+					Temperature:              []float64{4, 6, 8},
+					PrecipitationProbability: []float64{0, 50, 0},
+					WeatherState:             []string{"Overcast", "Overcast", "Overcast"},
+					WindSpeed:                []float64{15, 9, 1},
+				},
+			},
+			want: &EnsembleData{
+				Providers: []string{"openmeteo", "meteoblue"},
+				Time:      []int64{7200, 10800},
+				Temperature: []Spread{
+					{Mean: 3, Min: 2, Max: 4},
+					{Mean: 4.5, Min: 3, Max: 6},
+				},
+				PrecipitationProbability: []Spread{
+					{Mean: 10, Min: 0, Max: 20},
+					{Mean: 40, Min: 30, Max: 50},
+				},
+				WindSpeed: []Spread{
+					{Mean: 10, Min: 5, Max: 15},
+					{Mean: 7, Min: 5, Max: 9},
+				},
+			},
+		},
+		{
+			name: "No common hours",
+			members: []*WeatherData{
+				{Time: []int64{3600}, Temperature: []float64{1}, PrecipitationProbability: []float64{0}, WeatherState: []string{""}, WindSpeed: []float64{0}},
+				{Time: []int64{7200}, Temperature: []float64{1}, PrecipitationProbability: []float64{0}, WeatherState: []string{""}, WindSpeed: []float64{0}},
+			},
+			want: &EnsembleData{
+				Providers: []string{"openmeteo", "meteoblue"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewEnsemble([]string{"openmeteo", "meteoblue"}, tt.members)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewEnsemble() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
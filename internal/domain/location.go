@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Ranges of the coordinates and altitude of a location. The altitude, in
+// metres above sea level, spans the shore of the Dead Sea to the summit of
+// Mount Everest with some margin.
+const (
+	MinLatitude  = -90.0
+	MaxLatitude  = 90.0
+	MinLongitude = -180.0
+	MaxLongitude = 180.0
+	MinAltitude  = -500.0
+	MaxAltitude  = 9000.0
+)
+
+func ValidateLatitude(lat float64) error {
+	if lat < MinLatitude || lat > MaxLatitude {
+		return fmt.Errorf("latitude must be between %g and %g degrees", MinLatitude, MaxLatitude)
+	}
+	return nil
+}
+
+func ValidateLongitude(lng float64) error {
+	if lng < MinLongitude || lng > MaxLongitude {
+		return fmt.Errorf("longitude must be between %g and %g degrees", MinLongitude, MaxLongitude)
+	}
+	return nil
+}
+
+func ValidateAltitude(alt float64) error {
+	if alt < MinAltitude || alt > MaxAltitude {
+		return fmt.Errorf("altitude must be between %g and %g metres", MinAltitude, MaxAltitude)
+	}
+	return nil
+}
+
+// ValidateTimezone checks that name is an IANA timezone such as
+// Europe/Berlin.
+func ValidateTimezone(name string) error {
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("timezone %q is unknown, expected an IANA name such as Europe/Berlin", name)
+	}
+	return nil
+}
+
+// Location is the resolved location of a forecast.
+type Location struct {
+	// Name is the place or saved location name, empty for coordinates.
+	Name      string
+	Latitude  float64
+	Longitude float64
+
+	// Elevation in metres above sea level, nil when unknown.
+	Elevation *float64
+
+	// Timezone is the IANA timezone the times are shown in: the configured
+	// TimezoneOverride when set, else the one reported by the provider or
+	// the geocoder, else the one derived from the coordinates.
+	Timezone         string
+	TimezoneOverride string
+}
+
+// UseReportedTimezone switches to the timezone reported by a provider or
+// geocoder, unless the timezone is overridden. Empty and unknown names are
+// ignored.
+func (l *Location) UseReportedTimezone(name string) {
+	if l.TimezoneOverride != "" || name == "" || ValidateTimezone(name) != nil {
+		return
+	}
+	l.Timezone = name
+}
+
+// Zone loads the timezone of the location.
+func (l Location) Zone() (*time.Location, error) {
+	zone, err := time.LoadLocation(l.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("loading timezone: %w", err)
+	}
+	return zone, nil
+}
+
+// String describes the location, e.g. "Zermatt, Valais, Switzerland
+// (46.0207, 7.7491), 1608 m above sea level". The name and the elevation are
+// optional.
+func (l Location) String() string {
+	s := fmt.Sprintf("%.4f, %.4f", l.Latitude, l.Longitude)
+	if l.Name != "" {
+		s = fmt.Sprintf("%s (%s)", l.Name, s)
+	}
+	if l.Elevation != nil {
+		s += fmt.Sprintf(", %.0f m above sea level", *l.Elevation)
+	}
+	return s
+}
@@ -0,0 +1,81 @@
+package domain
+
+import "testing"
+
+func TestLocationUseReportedTimezone(t *testing.T) {
+	tests := []struct {
+		name     string
+		location Location
+		reported string
+		want     string
+	}{
+		{
+			name:     "Reported timezone replaces the derived one",
+			location: Location{Timezone: "Europe/Berlin"},
+			reported: "Europe/Zurich",
+			want:     "Europe/Zurich",
+		},
+		{
+			name:     "Override is kept",
+			location: Location{Timezone: "UTC", TimezoneOverride: "UTC"},
+			reported: "Europe/Zurich",
+			want:     "UTC",
+		},
+		{
+			name:     "Empty name is ignored",
+			location: Location{Timezone: "Europe/Berlin"},
+			want:     "Europe/Berlin",
+		},
+		{
+			name:     "Unknown name is ignored",
+			location: Location{Timezone: "Europe/Berlin"},
+			reported: "Mars/Olympus_Mons",
+			want:     "Europe/Berlin",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.location.UseReportedTimezone(tt.reported)
+			if tt.location.Timezone != tt.want {
+				t.Errorf("Location.Timezone = %q, want %q", tt.location.Timezone, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocationZone(t *testing.T) {
+	if _, err := (Location{Timezone: "Mars/Olympus_Mons"}).Zone(); err == nil {
+		t.Error("Location.Zone() with an unknown timezone succeeded")
+	}
+	zone, err := Location{Timezone: "Europe/Zurich"}.Zone()
+	if err != nil || zone.String() != "Europe/Zurich" {
+		t.Errorf("Location.Zone() = %v, %v, want Europe/Zurich", zone, err)
+	}
+}
+
+func TestLocationString(t *testing.T) {
+	elevation := 1608.4
+	tests := []struct {
+		name     string
+		location Location
+		want     string
+	}{
+		{
+			name:     "Coordinates",
+			location: Location{Latitude: 46.0207, Longitude: 7.7491},
+			want:     "46.0207, 7.7491",
+		},
+		{
+			name:     "Name and elevation",
+			location: Location{Name: "Zermatt", Latitude: 46.0207, Longitude: 7.7491, Elevation: &elevation},
+			want:     "Zermatt (46.0207, 7.7491), 1608 m above sea level",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.location.String(); got != tt.want {
+				t.Errorf("Location.String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -12,8 +12,14 @@ type MeteoblueMetadata struct {
 
 type MeteoblueData1h struct {
 	Time                     []int64
-	Temperature              []float64
-	PrecipitationProbability []float64
-	WindSpeed                []float64
+	Temperature              Series
+	PrecipitationProbability Series
+	WindSpeed                Series
 	Pictocode                []int64
+	FeltTemperature          Series
+	Precipitation            Series
+	RelativeHumidity         Series
+	SeaLevelPressure         Series
+	WindDirection            Series
+	UVIndex                  Series
 }
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrMalformed is returned for forecasts that cannot be shown, e.g. with
+// series of different lengths.
+var ErrMalformed = errors.New("malformed forecast")
+
+// Missing returns the value standing for a gap in a series, such as a null
+// in the provider response.
+func Missing() float64 {
+	return math.NaN()
+}
+
+// IsMissing reports whether v is a gap in a series.
+func IsMissing(v float64) bool {
+	return math.IsNaN(v)
+}
+
+// Series is an hourly series of values, with gaps marked by Missing. In
+// JSON, gaps are encoded as null.
+type Series []float64
+
+func (s Series) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	values := make([]*float64, len(s))
+	for i := range s {
+		if !IsMissing(s[i]) {
+			values[i] = &s[i]
+		}
+	}
+	return json.Marshal(values)
+}
+
+func (s *Series) UnmarshalJSON(data []byte) error {
+	var values []*float64
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*s = FromNullable(values)
+	return nil
+}
+
+// FromNullable converts decoded JSON values to a series, with nulls as
+// gaps. A nil slice, i.e. an absent variable, stays nil.
+func FromNullable(values []*float64) Series {
+	if values == nil {
+		return nil
+	}
+	s := make(Series, len(values))
+	for i, v := range values {
+		if v == nil {
+			s[i] = Missing()
+		} else {
+			s[i] = *v
+		}
+	}
+	return s
+}
+
+// Validate checks that every series, and the weather states, hold one
+// value per hour. Gaps are fine, they are shown as such.
+func (w *WeatherData) Validate() error {
+	if len(w.Time) == 0 {
+		return fmt.Errorf("%w: no hours", ErrMalformed)
+	}
+	if len(w.WeatherState) != len(w.Time) {
+		return fmt.Errorf("%w: %d weather states for %d hours", ErrMalformed, len(w.WeatherState), len(w.Time))
+	}
+	for _, v := range Variables {
+		series := w.Series(v)
+		if series != nil && len(series) != len(w.Time) {
+			return fmt.Errorf("%w: %d %s values for %d hours", ErrMalformed, len(series), v, len(w.Time))
+		}
+	}
+	for _, v := range []Variable{Temperature, PrecipitationProbability, WindSpeed} {
+		if !w.Has(v) {
+			return fmt.Errorf("%w: no %s values", ErrMalformed, v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestWeatherDataValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		weather *WeatherData
+		wantErr bool
+	}{
+		{
+			name: "Gaps are valid",
+			weather: &WeatherData{
+				Time:                     []int64{3600, 7200},
+				Temperature:              []float64{1, Missing()},
+				PrecipitationProbability: []float64{Missing(), Missing()},
+				WeatherState:             []string{"Fog", ""},
+				WindSpeed:                []float64{3, 4},
+			},
+		},
+		{
+			name: "Ragged series",
+			weather: &WeatherData{
+				Time:                     []int64{3600, 7200},
+				Temperature:              []float64{1},
+				PrecipitationProbability: []float64{0, 0},
+				WeatherState:             []string{"Fog", "Fog"},
+				WindSpeed:                []float64{3, 4},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Ragged optional series",
+			weather: &WeatherData{
+				Time:                     []int64{3600, 7200},
+				Temperature:              []float64{1, 2},
+				PrecipitationProbability: []float64{0, 0},
+				WeatherState:             []string{"Fog", "Fog"},
+				WindSpeed:                []float64{3, 4},
+				Humidity:                 []float64{80, 81, 82},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Missing weather states",
+			weather: &WeatherData{
+				Time:                     []int64{3600},
+				Temperature:              []float64{1},
+				PrecipitationProbability: []float64{0},
+				WindSpeed:                []float64{3},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Missing required series",
+			weather: &WeatherData{
+				Time:                     []int64{3600},
+				Temperature:              []float64{1},
+				PrecipitationProbability: []float64{0},
+				WeatherState:             []string{"Fog"},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "No hours",
+			weather: &WeatherData{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.weather.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrMalformed) {
+				t.Errorf("Validate() error = %v, want ErrMalformed", err)
+			}
+		})
+	}
+}
+
+func TestSeriesJSON(t *testing.T) {
+	in := Series{1.5, Missing(), 3}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "[1.5,null,3]" {
+		t.Errorf("Marshal() = %s, want [1.5,null,3]", data)
+	}
+
+	var out Series
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 3 || out[0] != 1.5 || !IsMissing(out[1]) || out[2] != 3 {
+		t.Errorf("Unmarshal() = %v, want [1.5 NaN 3]", out)
+	}
+
+	var absent struct{ Values Series }
+	if err := json.Unmarshal([]byte(`{"Values":null}`), &absent); err != nil {
+		t.Fatal(err)
+	}
+	if absent.Values != nil {
+		t.Errorf("Unmarshal(null) = %v, want nil", absent.Values)
+	}
+}
@@ -3,13 +3,24 @@ package domain
 type OpenmeteoWeatherData struct {
 	Latitude  float64
 	Longitude float64
+	Timezone  string
 	Hourly    OpenmeteoHourlyData
 }
 
 type OpenmeteoHourlyData struct {
 	Time                     []int64
-	Temperature              []float64
-	PrecipitationProbability []float64
+	Temperature              Series
+	PrecipitationProbability Series
 	WeatherCode              []int64
-	WindSpeed                []float64
+	WindSpeed                Series
+	ApparentTemperature      Series
+	Precipitation            Series
+	Humidity                 Series
+	DewPoint                 Series
+	Pressure                 Series
+	CloudCover               Series
+	WindDirection            Series
+	WindGusts                Series
+	UVIndex                  Series
+	Visibility               Series
 }
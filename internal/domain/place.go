@@ -0,0 +1,34 @@
+package domain
+
+import "strings"
+
+type Place struct {
+	Name        string
+	Admin1      string
+	Country     string
+	CountryCode string
+	Latitude    float64
+	Longitude   float64
+	Population  int64
+	Timezone    string
+
+	// Elevation in metres above sea level, nil when the geocoder does not
+	// know it.
+	Elevation *float64
+}
+
+// String returns the human readable name of the place,
+// e.g. "Berlin, Land Berlin, Germany".
+func (p Place) String() string {
+	parts := []string{p.Name}
+	if p.Admin1 != "" && p.Admin1 != p.Name {
+		parts = append(parts, p.Admin1)
+	}
+	switch {
+	case p.Country != "":
+		parts = append(parts, p.Country)
+	case p.CountryCode != "":
+		parts = append(parts, p.CountryCode)
+	}
+	return strings.Join(parts, ", ")
+}
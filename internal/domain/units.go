@@ -0,0 +1,156 @@
+package domain
+
+import "math"
+
+// Providers deliver temperatures in °C, wind speeds in km/h and
+// precipitation amounts in mm. The conversion functions below take values
+// in these units.
+
+type TemperatureUnit string
+
+const (
+	Celsius    TemperatureUnit = "C"
+	Fahrenheit TemperatureUnit = "F"
+	Kelvin     TemperatureUnit = "K"
+)
+
+type WindSpeedUnit string
+
+const (
+	KilometresPerHour WindSpeedUnit = "kmh"
+	MetresPerSecond   WindSpeedUnit = "ms"
+	MilesPerHour      WindSpeedUnit = "mph"
+	Knots             WindSpeedUnit = "kn"
+	Beaufort          WindSpeedUnit = "bft"
+)
+
+type PrecipitationUnit string
+
+const (
+	Millimetres PrecipitationUnit = "mm"
+	Inches      PrecipitationUnit = "in"
+)
+
+// Units selects the unit of every quantity. The zero value, like an empty
+// field, stands for the metric units the providers deliver.
+type Units struct {
+	Temperature   TemperatureUnit
+	WindSpeed     WindSpeedUnit
+	Precipitation PrecipitationUnit
+}
+
+var (
+	Metric   = Units{Temperature: Celsius, WindSpeed: KilometresPerHour, Precipitation: Millimetres}
+	Imperial = Units{Temperature: Fahrenheit, WindSpeed: MilesPerHour, Precipitation: Inches}
+)
+
+// Label returns the symbol printed after a temperature.
+func (u TemperatureUnit) Label() string {
+	switch u {
+	case Fahrenheit:
+		return "°F"
+	case Kelvin:
+		return "K"
+	default:
+		return "°C"
+	}
+}
+
+// Label returns the symbol printed after a wind speed.
+func (u WindSpeedUnit) Label() string {
+	switch u {
+	case MetresPerSecond:
+		return "m/s"
+	case MilesPerHour:
+		return "mph"
+	case Knots:
+		return "kn"
+	case Beaufort:
+		return "Bft"
+	default:
+		return "km/h"
+	}
+}
+
+// Label returns the symbol printed after a precipitation amount.
+func (u PrecipitationUnit) Label() string {
+	if u == Inches {
+		return "in"
+	}
+	return "mm"
+}
+
+// ConvertTemperature converts a temperature in °C to u.
+func ConvertTemperature(celsius float64, u TemperatureUnit) float64 {
+	switch u {
+	case Fahrenheit:
+		return celsius*9/5 + 32
+	case Kelvin:
+		return celsius + 273.15
+	default:
+		return celsius
+	}
+}
+
+// beaufortLimits are the upper bounds in km/h of the Beaufort forces 0 to 11.
+var beaufortLimits = []float64{1, 6, 12, 20, 29, 39, 50, 62, 75, 89, 103, 118}
+
+// ConvertWindSpeed converts a wind speed in km/h to u.
+func ConvertWindSpeed(kmh float64, u WindSpeedUnit) float64 {
+	switch u {
+	case MetresPerSecond:
+		return kmh / 3.6
+	case MilesPerHour:
+		return kmh / 1.609344
+	case Knots:
+		return kmh / 1.852
+	case Beaufort:
+		if IsMissing(kmh) {
+			return kmh
+		}
+		for force, limit := range beaufortLimits {
+			if kmh < limit {
+				return float64(force)
+			}
+		}
+		return float64(len(beaufortLimits))
+	default:
+		return kmh
+	}
+}
+
+// ConvertPrecipitation converts a precipitation amount in mm to u.
+func ConvertPrecipitation(mm float64, u PrecipitationUnit) float64 {
+	if u == Inches {
+		return mm / 25.4
+	}
+	return mm
+}
+
+// Convert returns a copy of the weather data with its values converted from
+// the provider units to u.
+func (w *WeatherData) Convert(u Units) *WeatherData {
+	c := *w
+	c.Units = u
+	for _, v := range Variables {
+		*c.series(v) = convert(w.Series(v), func(value float64) float64 { return v.convert(value, u) })
+	}
+	return &c
+}
+
+func convert(values []float64, f func(float64) float64) Series {
+	if values == nil {
+		return nil
+	}
+	converted := make(Series, len(values))
+	for i, v := range values {
+		converted[i] = round(f(v))
+	}
+	return converted
+}
+
+// round drops the noise the conversions add beyond the precision of the
+// forecasts.
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConvertTemperature(t *testing.T) {
+	tests := []struct {
+		celsius float64
+		unit    TemperatureUnit
+		want    float64
+	}{
+		{celsius: 20, unit: Celsius, want: 20},
+		{celsius: 20, unit: "", want: 20},
+		{celsius: 20, unit: Fahrenheit, want: 68},
+		{celsius: -40, unit: Fahrenheit, want: -40},
+		{celsius: 0, unit: Kelvin, want: 273.15},
+	}
+	for _, tt := range tests {
+		if got := ConvertTemperature(tt.celsius, tt.unit); got != tt.want {
+			t.Errorf("ConvertTemperature(%v, %q) = %v, want %v", tt.celsius, tt.unit, got, tt.want)
+		}
+	}
+}
+
+func TestConvertWindSpeed(t *testing.T) {
+	tests := []struct {
+		kmh  float64
+		unit WindSpeedUnit
+		want float64
+	}{
+		{kmh: 36, unit: KilometresPerHour, want: 36},
+		{kmh: 36, unit: MetresPerSecond, want: 10},
+		{kmh: 1.609344, unit: MilesPerHour, want: 1},
+		{kmh: 18.52, unit: Knots, want: 10},
+		{kmh: 0, unit: Beaufort, want: 0},
+		{kmh: 12, unit: Beaufort, want: 3},
+		{kmh: 40, unit: Beaufort, want: 6},
+		{kmh: 150, unit: Beaufort, want: 12},
+	}
+	for _, tt := range tests {
+		if got := ConvertWindSpeed(tt.kmh, tt.unit); got != tt.want {
+			t.Errorf("ConvertWindSpeed(%v, %q) = %v, want %v", tt.kmh, tt.unit, got, tt.want)
+		}
+	}
+}
+
+func TestConvertPrecipitation(t *testing.T) {
+	if got := ConvertPrecipitation(25.4, Inches); got != 1 {
+		t.Errorf("ConvertPrecipitation(25.4, in) = %v, want 1", got)
+	}
+	if got := ConvertPrecipitation(3, Millimetres); got != 3 {
+		t.Errorf("ConvertPrecipitation(3, mm) = %v, want 3", got)
+	}
+}
+
+func TestWeatherDataConvert(t *testing.T) {
+	weather := &WeatherData{
+		Time:                     []int64{3600},
+		Temperature:              []float64{21.3},
+		PrecipitationProbability: []float64{40},
+		WeatherState:             []string{"Fog"},
+		WindSpeed:                []float64{10},
+	}
+
+	got := weather.Convert(Imperial)
+	want := &WeatherData{
+		Time:                     []int64{3600},
+		Temperature:              []float64{70.34},
+		PrecipitationProbability: []float64{40},
+		WeatherState:             []string{"Fog"},
+		WindSpeed:                []float64{6.21},
+		Units:                    Imperial,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Convert() = %+v, want %+v", got, want)
+	}
+	if weather.Temperature[0] != 21.3 {
+		t.Errorf("Convert() modified the original data")
+	}
+}
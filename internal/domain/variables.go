@@ -0,0 +1,121 @@
+package domain
+
+// Variable names a numeric hourly series of WeatherData.
+type Variable string
+
+const (
+	Temperature              Variable = "temperature"
+	ApparentTemperature      Variable = "apparent_temperature"
+	PrecipitationProbability Variable = "precipitation_probability"
+	Precipitation            Variable = "precipitation"
+	Humidity                 Variable = "humidity"
+	DewPoint                 Variable = "dew_point"
+	Pressure                 Variable = "pressure"
+	CloudCover               Variable = "cloud_cover"
+	WindSpeed                Variable = "wind_speed"
+	WindDirection            Variable = "wind_direction"
+	WindGusts                Variable = "wind_gusts"
+	UVIndex                  Variable = "uv_index"
+	Visibility               Variable = "visibility"
+)
+
+// Variables lists every variable in display order.
+var Variables = []Variable{
+	Temperature,
+	ApparentTemperature,
+	PrecipitationProbability,
+	Precipitation,
+	Humidity,
+	DewPoint,
+	Pressure,
+	CloudCover,
+	WindSpeed,
+	WindDirection,
+	WindGusts,
+	UVIndex,
+	Visibility,
+}
+
+// Label returns the unit symbol of the variable in the units u, empty for
+// the UV index which has none.
+func (v Variable) Label(u Units) string {
+	switch v {
+	case Temperature, ApparentTemperature, DewPoint:
+		return u.Temperature.Label()
+	case WindSpeed, WindGusts:
+		return u.WindSpeed.Label()
+	case Precipitation:
+		return u.Precipitation.Label()
+	case PrecipitationProbability, Humidity, CloudCover:
+		return "%"
+	case Pressure:
+		return "hPa"
+	case WindDirection:
+		return "°"
+	case Visibility:
+		return "m"
+	default:
+		return ""
+	}
+}
+
+// convert converts a value of the variable from the provider units to u.
+func (v Variable) convert(value float64, u Units) float64 {
+	switch v {
+	case Temperature, ApparentTemperature, DewPoint:
+		return ConvertTemperature(value, u.Temperature)
+	case WindSpeed, WindGusts:
+		return ConvertWindSpeed(value, u.WindSpeed)
+	case Precipitation:
+		return ConvertPrecipitation(value, u.Precipitation)
+	default:
+		return value
+	}
+}
+
+// Series returns the values of the variable, nil when the provider does
+// not deliver it.
+func (w *WeatherData) Series(v Variable) []float64 {
+	if p := w.series(v); p != nil {
+		return *p
+	}
+	return nil
+}
+
+// Has reports whether the provider delivered the variable.
+func (w *WeatherData) Has(v Variable) bool {
+	return w.Series(v) != nil
+}
+
+func (w *WeatherData) series(v Variable) *Series {
+	switch v {
+	case Temperature:
+		return &w.Temperature
+	case ApparentTemperature:
+		return &w.ApparentTemperature
+	case PrecipitationProbability:
+		return &w.PrecipitationProbability
+	case Precipitation:
+		return &w.Precipitation
+	case Humidity:
+		return &w.Humidity
+	case DewPoint:
+		return &w.DewPoint
+	case Pressure:
+		return &w.Pressure
+	case CloudCover:
+		return &w.CloudCover
+	case WindSpeed:
+		return &w.WindSpeed
+	case WindDirection:
+		return &w.WindDirection
+	case WindGusts:
+		return &w.WindGusts
+	case UVIndex:
+		return &w.UVIndex
+	case Visibility:
+		return &w.Visibility
+	default:
+		return nil
+	}
+}
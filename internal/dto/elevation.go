@@ -0,0 +1,5 @@
+package dto
+
+type OpenmeteoElevationData struct {
+	Elevation []float64 `json:"elevation"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+type OpenmeteoGeocodingData struct {
+	Results []OpenmeteoGeocodingResult `json:"results"`
+}
+
+type OpenmeteoGeocodingResult struct {
+	Name        string   `json:"name"`
+	Latitude    float64  `json:"latitude"`
+	Longitude   float64  `json:"longitude"`
+	Elevation   *float64 `json:"elevation"`
+	CountryCode string   `json:"country_code"`
+	Country     string   `json:"country"`
+	Admin1      string   `json:"admin1"`
+	Timezone    string   `json:"timezone"`
+	Population  int64    `json:"population"`
+}
@@ -11,9 +11,20 @@ type MeteoblueMetadata struct {
 }
 
 type MeteoblueData1h struct {
-	Time                     []int64   `json:"time"`
-	Temperature              []float64 `json:"temperature"`
-	PrecipitationProbability []float64 `json:"precipitation_probability"`
-	WindSpeed                []float64 `json:"windspeed"`
-	Pictocode                []int64   `json:"pictocode"`
+	Time                     []*int64   `json:"time"`
+	Temperature              []*float64 `json:"temperature"`
+	PrecipitationProbability []*float64 `json:"precipitation_probability"`
+	WindSpeed                []*float64 `json:"windspeed"`
+	Pictocode                []*int64   `json:"pictocode"`
+	FeltTemperature          []*float64 `json:"felttemperature"`
+	Precipitation            []*float64 `json:"precipitation"`
+	RelativeHumidity         []*float64 `json:"relativehumidity"`
+	SeaLevelPressure         []*float64 `json:"sealevelpressure"`
+	WindDirection            []*float64 `json:"winddirection"`
+	UVIndex                  []*float64 `json:"uvindex"`
+}
+
+// MeteoblueError is the body of an error response.
+type MeteoblueError struct {
+	ErrorMessage string `json:"error_message"`
 }
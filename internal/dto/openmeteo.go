@@ -3,13 +3,30 @@ package dto
 type OpenmeteoWeatherData struct {
 	Latitude  float64             `json:"latitude"`
 	Longitude float64             `json:"longitude"`
+	Timezone  string              `json:"timezone"`
 	Hourly    OpenmeteoHourlyData `json:"hourly"`
 }
 
 type OpenmeteoHourlyData struct {
-	Time                     []int64   `json:"time"`
-	Temperature              []float64 `json:"temperature_2m"`
-	PrecipitationProbability []float64 `json:"precipitation_probability"`
-	WeatherCode              []int64   `json:"weathercode"`
-	WindSpeed                []float64 `json:"windspeed_10m"`
+	Time                     []*int64   `json:"time"`
+	Temperature              []*float64 `json:"temperature_2m"`
+	PrecipitationProbability []*float64 `json:"precipitation_probability"`
+	WeatherCode              []*int64   `json:"weathercode"`
+	WindSpeed                []*float64 `json:"windspeed_10m"`
+	ApparentTemperature      []*float64 `json:"apparent_temperature"`
+	Precipitation            []*float64 `json:"precipitation"`
+	Humidity                 []*float64 `json:"relativehumidity_2m"`
+	DewPoint                 []*float64 `json:"dewpoint_2m"`
+	Pressure                 []*float64 `json:"pressure_msl"`
+	CloudCover               []*float64 `json:"cloudcover"`
+	WindDirection            []*float64 `json:"winddirection_10m"`
+	WindGusts                []*float64 `json:"windgusts_10m"`
+	UVIndex                  []*float64 `json:"uv_index"`
+	Visibility               []*float64 `json:"visibility"`
+}
+
+// OpenmeteoError is the body of an error response.
+type OpenmeteoError struct {
+	Error  bool   `json:"error"`
+	Reason string `json:"reason"`
 }
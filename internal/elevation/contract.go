@@ -0,0 +1,9 @@
+package elevation
+
+import "context"
+
+// Contract is implemented by services which look up the elevation of the
+// terrain, in metres above sea level, from a digital elevation model.
+type Contract interface {
+	Lookup(ctx context.Context, lat, lng float64) (float64, error)
+}
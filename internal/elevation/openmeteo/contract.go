@@ -0,0 +1,7 @@
+package openmeteo
+
+import "net/http"
+
+type httpClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
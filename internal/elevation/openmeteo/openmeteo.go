@@ -0,0 +1,75 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"meteo/internal/dto"
+	"meteo/internal/elevation"
+	"meteo/internal/services"
+)
+
+const (
+	// The elevation API is based on the Copernicus DEM with a resolution of
+	// 90 metres, the model the forecast API downscales to by default.
+	baseURL = "https://api.open-meteo.com/v1/elevation?latitude=%f&longitude=%f"
+
+	// providerName names the elevation API in errors.
+	providerName = "openmeteo elevation"
+)
+
+type openmeteo struct {
+	client httpClient
+}
+
+func NewOpenmeteo(client httpClient) elevation.Contract {
+	return &openmeteo{
+		client: client,
+	}
+}
+
+func (om *openmeteo) Lookup(ctx context.Context, lat, lng float64) (float64, error) {
+	elevationDto := dto.OpenmeteoElevationData{}
+
+	if err := services.ValidateCoordinates(lat, lng); err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, createURL(lat, lng), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := om.client.Do(req)
+	if err != nil {
+		return 0, services.RequestError(ctx, providerName, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, services.RequestError(ctx, providerName, err)
+	}
+
+	if resp.StatusCode != 200 {
+		var reason dto.OpenmeteoError
+		_ = json.Unmarshal(body, &reason)
+		return 0, services.StatusError(providerName, resp.StatusCode, reason.Reason)
+	}
+
+	err = json.Unmarshal(body, &elevationDto)
+	if err != nil {
+		return 0, services.ResponseError(providerName, err)
+	}
+	if len(elevationDto.Elevation) != 1 {
+		return 0, services.ResponseError(providerName, errors.New("expected one elevation"))
+	}
+
+	return elevationDto.Elevation[0], nil
+}
+
+func createURL(lat, lng float64) string {
+	return fmt.Sprintf(baseURL, lat, lng)
+}
@@ -0,0 +1,92 @@
+package openmeteo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"meteo/internal/elevation/openmeteo/mocks"
+	"meteo/internal/services"
+	"net/http"
+	"testing"
+)
+
+func Test_openmeteo_Lookup(t *testing.T) {
+	tests := []struct {
+		name         string
+		lat          float64
+		lng          float64
+		mockResponse string
+		mockStatus   int
+		mockError    error
+		want         float64
+		wantErr      error
+	}{
+		{
+			name:         "successful API call",
+			lat:          46.02,
+			lng:          7.75,
+			mockResponse: `{"elevation":[1608.0]}`,
+			mockStatus:   http.StatusOK,
+			want:         1608,
+		},
+		{
+			name:         "Below sea level",
+			lat:          31.5,
+			lng:          35.5,
+			mockResponse: `{"elevation":[-415.0]}`,
+			mockStatus:   http.StatusOK,
+			want:         -415,
+		},
+		{
+			name:         "No elevation",
+			mockResponse: `{"elevation":[]}`,
+			mockStatus:   http.StatusOK,
+			wantErr:      services.ErrInvalidResponse,
+		},
+		{
+			name:         "API returns non-200 status",
+			mockResponse: `{"error":true,"reason":"Internal error"}`,
+			mockStatus:   http.StatusInternalServerError,
+			wantErr:      services.ErrUpstreamUnavailable,
+		},
+		{
+			name:      "HTTP client error",
+			mockError: errors.New("network error"),
+			wantErr:   services.ErrUpstreamUnavailable,
+		},
+		{
+			name:    "Invalid latitude",
+			lat:     91,
+			wantErr: services.ErrInvalidLocation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mocks.MockClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: tt.mockStatus,
+						Body:       io.NopCloser(bytes.NewReader([]byte(tt.mockResponse))),
+					}, tt.mockError
+				},
+			}
+
+			got, err := NewOpenmeteo(client).Lookup(context.Background(), tt.lat, tt.lng)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("openmeteo.Lookup() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("openmeteo.Lookup() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_createURL(t *testing.T) {
+	want := "https://api.open-meteo.com/v1/elevation?latitude=46.020000&longitude=7.750000"
+	if got := createURL(46.02, 7.75); got != want {
+		t.Errorf("createURL() = %v, want %v", got, want)
+	}
+}
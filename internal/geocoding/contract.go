@@ -0,0 +1,12 @@
+package geocoding
+
+import (
+	"context"
+
+	"meteo/internal/domain"
+)
+
+// Contract is implemented by geocoders which look up places by name.
+type Contract interface {
+	Search(ctx context.Context, name string) ([]domain.Place, error)
+}
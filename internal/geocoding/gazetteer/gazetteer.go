@@ -0,0 +1,88 @@
+package gazetteer
+
+import (
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"meteo/internal/domain"
+	"meteo/internal/geocoding"
+)
+
+// cities is an offline list of major cities, used when the geocoding API
+// is not reachable or not wanted.
+//
+//go:embed cities.csv
+var cities string
+
+type gazetteer struct {
+	places []domain.Place
+}
+
+func NewGazetteer() (geocoding.Contract, error) {
+	places, err := parseCities(cities)
+	if err != nil {
+		return nil, err
+	}
+	return &gazetteer{
+		places: places,
+	}, nil
+}
+
+// Search returns the cities whose name matches case-insensitively,
+// most populous first.
+func (g *gazetteer) Search(_ context.Context, name string) ([]domain.Place, error) {
+	var places []domain.Place
+	for _, p := range g.places {
+		if strings.EqualFold(p.Name, name) {
+			places = append(places, p)
+		}
+	}
+	sort.SliceStable(places, func(i, j int) bool {
+		return places[i].Population > places[j].Population
+	})
+	return places, nil
+}
+
+func parseCities(data string) ([]domain.Place, error) {
+	records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("empty gazetteer")
+	}
+
+	// Skip the header row.
+	places := make([]domain.Place, 0, len(records)-1)
+	for i, r := range records[1:] {
+		lat, err := strconv.ParseFloat(r[4], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid latitude: %w", i+2, err)
+		}
+		lng, err := strconv.ParseFloat(r[5], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid longitude: %w", i+2, err)
+		}
+		population, err := strconv.ParseInt(r[6], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid population: %w", i+2, err)
+		}
+
+		places = append(places, domain.Place{
+			Name:        r[0],
+			Admin1:      r[1],
+			CountryCode: r[2],
+			Country:     r[3],
+			Latitude:    lat,
+			Longitude:   lng,
+			Population:  population,
+			Timezone:    r[7],
+		})
+	}
+	return places, nil
+}
@@ -0,0 +1,87 @@
+package gazetteer
+
+import (
+	"context"
+	"errors"
+	"meteo/internal/geocoding"
+	"testing"
+	"time"
+)
+
+func Test_parseCities(t *testing.T) {
+	places, err := parseCities(cities)
+	if err != nil {
+		t.Fatalf("parseCities() error = %v", err)
+	}
+	for _, p := range places {
+		if _, err := geocoding.Validate(&p); err != nil {
+			t.Errorf("%s: %v", p, err)
+		}
+		if _, err := time.LoadLocation(p.Timezone); err != nil {
+			t.Errorf("%s: invalid timezone: %v", p, err)
+		}
+	}
+}
+
+func Test_gazetteer_Resolve(t *testing.T) {
+	g, err := NewGazetteer()
+	if err != nil {
+		t.Fatalf("NewGazetteer() error = %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		query         string
+		wantCountry   string
+		wantAmbiguous bool
+		wantErr       bool
+	}{
+		{
+			name:        "Unique name",
+			query:       "Berlin",
+			wantCountry: "DE",
+		},
+		{
+			name:        "Case insensitive",
+			query:       "new york",
+			wantCountry: "US",
+		},
+		{
+			name:          "Ambiguous name",
+			query:         "Valencia",
+			wantAmbiguous: true,
+			wantErr:       true,
+		},
+		{
+			name:        "Country code qualifier",
+			query:       "Valencia, VE",
+			wantCountry: "VE",
+		},
+		{
+			name:        "Region qualifier",
+			query:       "Portland, Maine",
+			wantCountry: "US",
+		},
+		{
+			name:    "Unknown place",
+			query:   "Atlantis",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := geocoding.Resolve(context.Background(), g, tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var ambiguous *geocoding.AmbiguousError
+			if errors.As(err, &ambiguous) != tt.wantAmbiguous {
+				t.Errorf("Resolve() error = %v, wantAmbiguous %v", err, tt.wantAmbiguous)
+			}
+			if !tt.wantErr && got.CountryCode != tt.wantCountry {
+				t.Errorf("Resolve() country = %v, want %v", got.CountryCode, tt.wantCountry)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package geocoding
+
+import (
+	"meteo/internal/domain"
+
+	"github.com/zsefvlol/timezonemapper"
+)
+
+// NewLocation resolves the location of a forecast at the given coordinates.
+// Its timezone is override when set, else reported, e.g. by the geocoder,
+// else derived from the coordinates with an offline timezone map.
+func NewLocation(name string, lat, lng float64, override, reported string) domain.Location {
+	loc := domain.Location{
+		Name:             name,
+		Latitude:         lat,
+		Longitude:        lng,
+		Timezone:         override,
+		TimezoneOverride: override,
+	}
+	loc.UseReportedTimezone(reported)
+	if loc.Timezone == "" {
+		loc.Timezone = timezonemapper.LatLngToTimezoneString(lat, lng)
+	}
+	return loc
+}
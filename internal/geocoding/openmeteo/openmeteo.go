@@ -0,0 +1,87 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"meteo/internal/domain"
+	"meteo/internal/dto"
+	"meteo/internal/geocoding"
+	"meteo/internal/services"
+)
+
+const (
+	baseURL = "https://geocoding-api.open-meteo.com/v1/search?name=%s&count=%d&language=en&format=json"
+
+	// maxResults limits the number of candidates offered for disambiguation.
+	maxResults = 10
+
+	// providerName names the geocoding API in errors.
+	providerName = "openmeteo geocoding"
+)
+
+type openmeteo struct {
+	client httpClient
+}
+
+func NewOpenmeteo(client httpClient) geocoding.Contract {
+	return &openmeteo{
+		client: client,
+	}
+}
+
+func (om *openmeteo) Search(ctx context.Context, name string) ([]domain.Place, error) {
+	geocodingDto := dto.OpenmeteoGeocodingData{}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, createURL(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := om.client.Do(req)
+	if err != nil {
+		return nil, services.RequestError(ctx, providerName, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, services.RequestError(ctx, providerName, err)
+	}
+
+	if resp.StatusCode != 200 {
+		var reason dto.OpenmeteoError
+		_ = json.Unmarshal(body, &reason)
+		return nil, services.StatusError(providerName, resp.StatusCode, reason.Reason)
+	}
+
+	err = json.Unmarshal(body, &geocodingDto)
+	if err != nil {
+		return nil, services.ResponseError(providerName, err)
+	}
+
+	// Convert dto to domain.
+	places := make([]domain.Place, len(geocodingDto.Results))
+	for i, r := range geocodingDto.Results {
+		places[i] = domain.Place{
+			Name:        r.Name,
+			Admin1:      r.Admin1,
+			Country:     r.Country,
+			CountryCode: r.CountryCode,
+			Latitude:    r.Latitude,
+			Longitude:   r.Longitude,
+			Population:  r.Population,
+			Timezone:    r.Timezone,
+			Elevation:   r.Elevation,
+		}
+	}
+
+	return places, nil
+}
+
+func createURL(name string) string {
+	return fmt.Sprintf(baseURL, url.QueryEscape(name), maxResults)
+}
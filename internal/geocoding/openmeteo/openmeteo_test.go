@@ -0,0 +1,117 @@
+package openmeteo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"meteo/internal/domain"
+	"meteo/internal/geocoding/openmeteo/mocks"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func Test_openmeteo_Search(t *testing.T) {
+	berlinElevation := 74.0
+	tests := []struct {
+		name         string
+		mockResponse string
+		mockStatus   int
+		mockError    error
+		want         []domain.Place
+		wantErr      bool
+	}{
+		{
+			name: "successful API call",
+			mockResponse: `{"results":[{"name":"Berlin","latitude":52.52437,"longitude":13.41053,"elevation":74.0,
+				"country_code":"DE","country":"Germany","admin1":"Land Berlin","timezone":"Europe/Berlin","population":3426354}]}`,
+			mockStatus: http.StatusOK,
+			want: []domain.Place{
+				{
+					Name:        "Berlin",
+					Admin1:      "Land Berlin",
+					Country:     "Germany",
+					CountryCode: "DE",
+					Latitude:    52.52437,
+					Longitude:   13.41053,
+					Population:  3426354,
+					Timezone:    "Europe/Berlin",
+					Elevation:   &berlinElevation,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:         "No results",
+			mockResponse: `{"generationtime_ms":0.5}`,
+			mockStatus:   http.StatusOK,
+			want:         []domain.Place{},
+			wantErr:      false,
+		},
+		{
+			name:         "API returns non-200 status",
+			mockResponse: `{"error":true,"reason":"Parameter count must be between 1 and 100."}`,
+			mockStatus:   http.StatusBadRequest,
+			wantErr:      true,
+		},
+		{
+			name:      "HTTP client error",
+			mockError: errors.New("network error"),
+			wantErr:   true,
+		},
+		{
+			name:         "Error unmarshaling body",
+			mockResponse: `{"broken json": {`,
+			mockStatus:   http.StatusOK,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mocks.MockClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: tt.mockStatus,
+						Body:       io.NopCloser(bytes.NewReader([]byte(tt.mockResponse))),
+					}, tt.mockError
+				},
+			}
+
+			got, err := NewOpenmeteo(client).Search(context.Background(), "Berlin")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("openmeteo.Search() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("openmeteo.Search() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_createURL(t *testing.T) {
+	tests := []struct {
+		name  string
+		place string
+		want  string
+	}{
+		{
+			name:  "Single word",
+			place: "Berlin",
+			want:  "https://geocoding-api.open-meteo.com/v1/search?name=Berlin&count=10&language=en&format=json",
+		},
+		{
+			name:  "Escaped characters",
+			place: "São Paulo",
+			want:  "https://geocoding-api.open-meteo.com/v1/search?name=S%C3%A3o+Paulo&count=10&language=en&format=json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := createURL(tt.place); got != tt.want {
+				t.Errorf("createURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
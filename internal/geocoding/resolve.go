@@ -0,0 +1,73 @@
+package geocoding
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"meteo/internal/domain"
+	"meteo/internal/services"
+)
+
+// AmbiguousError is returned by Resolve when a query matches several places.
+type AmbiguousError struct {
+	Query      string
+	Candidates []domain.Place
+}
+
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf("%q matches %d places", e.Query, len(e.Candidates))
+}
+
+// ParseQuery splits a query such as "Berlin, DE" into the place name and an
+// optional qualifier which narrows down the country or region.
+func ParseQuery(query string) (name, qualifier string) {
+	name, qualifier, _ = strings.Cut(query, ",")
+	return strings.TrimSpace(name), strings.TrimSpace(qualifier)
+}
+
+// Resolve looks up a single place for the query. The qualifier part of the
+// query is matched against the country code, country and region names of
+// the candidates. An *AmbiguousError is returned when several places remain.
+func Resolve(ctx context.Context, g Contract, query string) (*domain.Place, error) {
+	name, qualifier := ParseQuery(query)
+	if name == "" {
+		return nil, fmt.Errorf("empty location name")
+	}
+
+	places, err := g.Search(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []domain.Place
+	for _, p := range places {
+		if qualifier == "" || matchesQualifier(p, qualifier) {
+			candidates = append(candidates, p)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("%w: location %q not found", services.ErrInvalidLocation, query)
+	case 1:
+		return Validate(&candidates[0])
+	default:
+		return nil, &AmbiguousError{Query: query, Candidates: candidates}
+	}
+}
+
+// Validate checks that the coordinates of a geocoded place are usable by the
+// weather providers.
+func Validate(p *domain.Place) (*domain.Place, error) {
+	if err := services.ValidateCoordinates(p.Latitude, p.Longitude); err != nil {
+		return nil, fmt.Errorf("invalid coordinates for %s: %w", p, err)
+	}
+	return p, nil
+}
+
+func matchesQualifier(p domain.Place, qualifier string) bool {
+	return strings.EqualFold(p.CountryCode, qualifier) ||
+		strings.EqualFold(p.Country, qualifier) ||
+		strings.EqualFold(p.Admin1, qualifier)
+}
@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"meteo/config"
+	"meteo/internal/domain"
+	"meteo/internal/services"
+)
+
+type cache struct {
+	next     services.Contract
+	provider string
+	store    *Store
+	ttl      time.Duration
+
+	// now is replaced in tests.
+	now func() time.Time
+}
+
+// New wraps the provider service so that forecasts younger than ttl are
+// served from the store instead of the provider. When the provider fails,
+// the most recent forecast stored for the location is returned as stale
+// data instead, however old it is.
+func New(next services.Contract, provider string, store *Store, ttl time.Duration) services.Contract {
+	return &cache{
+		next:     next,
+		provider: provider,
+		store:    store,
+		ttl:      ttl,
+		now:      time.Now,
+	}
+}
+
+func (c *cache) Get(ctx context.Context, loc domain.Location, cfg *config.Config) (*domain.WeatherData, error) {
+	key := Key(c.provider, loc, cfg.Days)
+
+	// An unreadable entry is treated as a miss and overwritten below.
+	entry, _ := c.store.Load(key)
+	if entry != nil && c.now().Sub(entry.FetchedAt) < c.ttl {
+		return entry.Weather, nil
+	}
+
+	data, err := c.next.Get(ctx, loc, cfg)
+	if err != nil {
+		if stale := c.fallback(loc, err); stale != nil {
+			return stale, nil
+		}
+		return nil, err
+	}
+
+	// Failing to cache must not fail the forecast.
+	fetched := &Entry{FetchedAt: c.now(), Weather: data}
+	_ = c.store.Save(key, fetched)
+	_ = c.store.Save(LocationKey(c.provider, loc), fetched)
+	return data, nil
+}
+
+// fallback returns the last forecast stored for the location, marked as
+// stale, or nil if there is none. It only stands in for failures of the
+// network or the provider. Interruptions by the user and errors the user has
+// to fix, such as a rejected API key or too many forecast days, are not
+// covered up.
+func (c *cache) fallback(loc domain.Location, err error) *domain.WeatherData {
+	if errors.Is(err, context.Canceled) || errors.Is(err, services.ErrUnauthorized) || errors.Is(err, services.ErrInvalidLocation) {
+		return nil
+	}
+	if !unreachable(err) {
+		return nil
+	}
+
+	entry, _ := c.store.Load(LocationKey(c.provider, loc))
+	if entry == nil || entry.Weather == nil {
+		return nil
+	}
+
+	stale := *entry.Weather
+	stale.Stale = true
+	stale.FetchedAt = entry.FetchedAt
+	return &stale
+}
+
+// unreachable reports whether err is a failure of the network or the
+// provider, or a timeout.
+func unreachable(err error) bool {
+	for _, kind := range []error{services.ErrUpstreamUnavailable, services.ErrRateLimited, services.ErrInvalidResponse, context.DeadlineExceeded} {
+		if errors.Is(err, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// Key identifies a forecast request. Coordinates are rounded to two
+// decimals, about one kilometre, so that nearby requests share an entry.
+// The altitude, when given, is part of the key since the providers adjust
+// the forecast to it.
+func Key(provider string, loc domain.Location, days int) string {
+	id := fmt.Sprintf("%s|%.2f|%.2f|%d", provider, loc.Latitude, loc.Longitude, days) + altitudeID(loc)
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:16])
+}
+
+// LocationKey identifies the most recent forecast of a location, whatever
+// the parameters it was requested with.
+func LocationKey(provider string, loc domain.Location) string {
+	id := fmt.Sprintf("%s|%.2f|%.2f|last", provider, loc.Latitude, loc.Longitude) + altitudeID(loc)
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:16])
+}
+
+// altitudeID is the altitude part of a key, empty without an altitude so
+// that such keys stay as they were.
+func altitudeID(loc domain.Location) string {
+	if loc.Elevation == nil {
+		return ""
+	}
+	return fmt.Sprintf("|%.0fm", *loc.Elevation)
+}
@@ -0,0 +1,256 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"meteo/config"
+	"meteo/internal/domain"
+	"meteo/internal/services"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type mockService struct {
+	data  *domain.WeatherData
+	err   error
+	calls int
+}
+
+func (m *mockService) Get(_ context.Context, _ domain.Location, _ *config.Config) (*domain.WeatherData, error) {
+	m.calls++
+	return m.data, m.err
+}
+
+func Test_cache_Get(t *testing.T) {
+	fresh := &domain.WeatherData{Time: []int64{1609459200}, Temperature: []float64{1.5}}
+	cached := &domain.WeatherData{Time: []int64{1609455600}, Temperature: []float64{0.5}}
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	staleCached := *cached
+	staleCached.Stale = true
+	staleCached.FetchedAt = now.Add(-26 * time.Hour)
+
+	tests := []struct {
+		name       string
+		stored     *Entry
+		storedLast *Entry
+		service    *mockService
+		want       *domain.WeatherData
+		wantErr    bool
+		wantCalls  int
+	}{
+		{
+			name:      "Miss fetches from provider",
+			service:   &mockService{data: fresh},
+			want:      fresh,
+			wantCalls: 1,
+		},
+		{
+			name:      "Fresh entry is served from cache",
+			stored:    &Entry{FetchedAt: now.Add(-5 * time.Minute), Weather: cached},
+			service:   &mockService{data: fresh},
+			want:      cached,
+			wantCalls: 0,
+		},
+		{
+			name:      "Expired entry is refreshed",
+			stored:    &Entry{FetchedAt: now.Add(-time.Hour), Weather: cached},
+			service:   &mockService{data: fresh},
+			want:      fresh,
+			wantCalls: 1,
+		},
+		{
+			name:      "Provider error",
+			service:   &mockService{err: errors.New("unexpected status code: 500")},
+			wantErr:   true,
+			wantCalls: 1,
+		},
+		{
+			name:       "Provider error falls back to the last forecast",
+			storedLast: &Entry{FetchedAt: now.Add(-26 * time.Hour), Weather: cached},
+			service:    &mockService{err: services.StatusError("openmeteo", 500, "")},
+			want:       &staleCached,
+			wantCalls:  1,
+		},
+		{
+			name:       "Timeout falls back to the last forecast",
+			storedLast: &Entry{FetchedAt: now.Add(-26 * time.Hour), Weather: cached},
+			service:    &mockService{err: &services.ProviderError{Provider: "openmeteo", Err: context.DeadlineExceeded}},
+			want:       &staleCached,
+			wantCalls:  1,
+		},
+		{
+			name:       "Invalid forecast days are not covered up",
+			storedLast: &Entry{FetchedAt: now.Add(-26 * time.Hour), Weather: cached},
+			service:    &mockService{err: services.ValidateForecastDays(20, 16)},
+			wantErr:    true,
+			wantCalls:  1,
+		},
+		{
+			name:       "Config errors are not covered up",
+			storedLast: &Entry{FetchedAt: now.Add(-26 * time.Hour), Weather: cached},
+			service:    &mockService{err: errors.New("meteoblue-api-key-file: open key: no such file or directory")},
+			wantErr:    true,
+			wantCalls:  1,
+		},
+		{
+			name:       "Interruption is not covered up",
+			storedLast: &Entry{FetchedAt: now.Add(-26 * time.Hour), Weather: cached},
+			service:    &mockService{err: context.Canceled},
+			wantErr:    true,
+			wantCalls:  1,
+		},
+		{
+			name:       "Rejected credentials are not covered up",
+			storedLast: &Entry{FetchedAt: now.Add(-26 * time.Hour), Weather: cached},
+			service:    &mockService{err: services.StatusError("meteoblue", 401, "invalid key")},
+			wantErr:    true,
+			wantCalls:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := domain.Location{Latitude: 52.52, Longitude: 13.405}
+			cfg := &config.Config{Days: 3}
+			store := NewStore(t.TempDir())
+			if tt.stored != nil {
+				if err := store.Save(Key("openmeteo", loc, cfg.Days), tt.stored); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.storedLast != nil {
+				if err := store.Save(LocationKey("openmeteo", loc), tt.storedLast); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			c := New(tt.service, "openmeteo", store, 15*time.Minute).(*cache)
+			c.now = func() time.Time { return now }
+
+			got, err := c.Get(context.Background(), loc, cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("cache.Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("cache.Get() = %+v, want %+v", got, tt.want)
+			}
+			if tt.service.calls != tt.wantCalls {
+				t.Errorf("provider calls = %d, want %d", tt.service.calls, tt.wantCalls)
+			}
+
+			// A successful fetch is stored for the next call.
+			if !tt.wantErr && tt.wantCalls > 0 && tt.service.err == nil {
+				entry, err := store.Load(Key("openmeteo", loc, cfg.Days))
+				if err != nil || entry == nil || !reflect.DeepEqual(entry.Weather, tt.want) {
+					t.Errorf("stored entry = %+v, %v, want %+v", entry, err, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	base := domain.Location{Latitude: 52.5201, Longitude: 13.4021}
+
+	tests := []struct {
+		name     string
+		provider string
+		loc      domain.Location
+		days     int
+		wantSame bool
+	}{
+		{
+			name:     "Nearby coordinates share a key",
+			provider: "openmeteo",
+			loc:      domain.Location{Latitude: 52.5199, Longitude: 13.4039},
+			days:     3,
+			wantSame: true,
+		},
+		{
+			name:     "Name and timezone do not matter",
+			provider: "openmeteo",
+			loc:      domain.Location{Name: "Berlin", Latitude: 52.5201, Longitude: 13.4021, Timezone: "Europe/Berlin"},
+			days:     3,
+			wantSame: true,
+		},
+		{
+			name:     "Different provider",
+			provider: "meteoblue",
+			loc:      base,
+			days:     3,
+			wantSame: false,
+		},
+		{
+			name:     "Different altitude",
+			provider: "openmeteo",
+			loc:      domain.Location{Latitude: 52.5201, Longitude: 13.4021, Elevation: ptr(34.0)},
+			days:     3,
+			wantSame: false,
+		},
+		{
+			name:     "Different horizon",
+			provider: "openmeteo",
+			loc:      base,
+			days:     7,
+			wantSame: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			same := Key("openmeteo", base, 3) == Key(tt.provider, tt.loc, tt.days)
+			if same != tt.wantSame {
+				t.Errorf("Key() same = %v, want %v", same, tt.wantSame)
+			}
+		})
+	}
+}
+
+type mockElevation struct {
+	alt   float64
+	err   error
+	calls int
+}
+
+func (m *mockElevation) Lookup(_ context.Context, _, _ float64) (float64, error) {
+	m.calls++
+	return m.alt, m.err
+}
+
+func Test_elevationCache_Lookup(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	failing := &mockElevation{err: errors.New("network error")}
+	if _, err := NewElevation(failing, store).Lookup(context.Background(), 46.02, 7.75); err == nil {
+		t.Fatal("Lookup() with a failing service succeeded")
+	}
+
+	service := &mockElevation{alt: 1608}
+	c := NewElevation(service, store)
+	for i := 0; i < 2; i++ {
+		got, err := c.Lookup(context.Background(), 46.02, 7.75)
+		if err != nil || got != 1608 {
+			t.Fatalf("Lookup() = %v, %v, want 1608", got, err)
+		}
+	}
+	if service.calls != 1 {
+		t.Errorf("Lookup() called the service %d times, want 1", service.calls)
+	}
+}
+
+func TestStore_Clear(t *testing.T) {
+	store := NewStore(t.TempDir())
+	if err := store.Save("key", &Entry{Weather: &domain.WeatherData{}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Clear(); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if entry, err := store.Load("key"); entry != nil || err != nil {
+		t.Errorf("Load() after Clear() = %+v, %v, want nil", entry, err)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
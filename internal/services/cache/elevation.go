@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"meteo/internal/elevation"
+)
+
+type elevationCache struct {
+	next  elevation.Contract
+	store *Store
+}
+
+// NewElevation wraps the elevation service so that every location is only
+// looked up once. The terrain does not change, so entries never expire.
+func NewElevation(next elevation.Contract, store *Store) elevation.Contract {
+	return &elevationCache{
+		next:  next,
+		store: store,
+	}
+}
+
+func (c *elevationCache) Lookup(ctx context.Context, lat, lng float64) (float64, error) {
+	key := ElevationKey(lat, lng)
+
+	// An unreadable entry is treated as a miss and overwritten below.
+	var stored float64
+	if found, _ := c.store.load(key, &stored); found {
+		return stored, nil
+	}
+
+	alt, err := c.next.Lookup(ctx, lat, lng)
+	if err != nil {
+		return 0, err
+	}
+	// Failing to cache must not fail the lookup.
+	_ = c.store.save(key, alt)
+	return alt, nil
+}
+
+// ElevationKey identifies the elevation of a location. Coordinates are
+// rounded to four decimals, about ten metres, finer than the elevation
+// model.
+func ElevationKey(lat, lng float64) string {
+	id := fmt.Sprintf("elevation|%.4f|%.4f", lat, lng)
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"meteo/internal/domain"
+)
+
+// Entry is a forecast as stored on disk.
+type Entry struct {
+	FetchedAt time.Time
+	Weather   *domain.WeatherData
+}
+
+// Store keeps one JSON file per cache key in a directory.
+type Store struct {
+	dir string
+}
+
+func NewStore(dir string) *Store {
+	return &Store{
+		dir: dir,
+	}
+}
+
+// DefaultDir returns the meteo directory below the user cache directory,
+// $XDG_CACHE_HOME or ~/.cache on Linux.
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "meteo"), nil
+}
+
+// Load returns the entry stored under key, or nil if there is none.
+func (s *Store) Load(key string) (*Entry, error) {
+	var entry Entry
+	if found, err := s.load(key, &entry); !found {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// Save stores the entry under key, replacing any previous one atomically.
+func (s *Store) Save(key string, entry *Entry) error {
+	return s.save(key, entry)
+}
+
+// load decodes the value stored under key into v and reports whether there
+// was one.
+func (s *Store) load(key string, v any) (bool, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("corrupt cache entry %s: %w", s.path(key), err)
+	}
+	return true, nil
+}
+
+// save stores v under key, replacing any previous value atomically.
+func (s *Store) save(key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(s.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), s.path(key))
+}
+
+// Clear removes all stored entries.
+func (s *Store) Clear() error {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, e.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) path(key string) string {
+	return filepath.Join(s.dir, key+".json")
+}
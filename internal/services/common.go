@@ -1,13 +1,52 @@
 package services
 
-import "fmt"
+import (
+	"fmt"
+
+	"meteo/internal/domain"
+)
 
 func ValidateCoordinates(lat, lng float64) error {
-	if lat < -90 || lat > 90 {
-		return fmt.Errorf("latitude must be between -90 and 90 degrees")
+	if err := domain.ValidateLatitude(lat); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidLocation, err)
+	}
+	if err := domain.ValidateLongitude(lng); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidLocation, err)
 	}
-	if lng < -180 || lng > 180 {
-		return fmt.Errorf("longitude must be between -180 and 180 degrees")
+	return nil
+}
+
+func ValidateForecastDays(days, maxDays int) error {
+	if days < 1 || days > maxDays {
+		return fmt.Errorf("forecast days must be between 1 and %d for this provider", maxDays)
 	}
 	return nil
 }
+
+// UnknownCode stands for a null weather code, it matches no condition.
+const UnknownCode = -1
+
+// Timestamps converts the decoded time axis of a response. A null time
+// cannot be placed, so it makes the response malformed.
+func Timestamps(values []*int64) ([]int64, error) {
+	times := make([]int64, len(values))
+	for i, v := range values {
+		if v == nil {
+			return nil, fmt.Errorf("%w: null time at index %d", domain.ErrMalformed, i)
+		}
+		times[i] = *v
+	}
+	return times, nil
+}
+
+// Codes converts decoded weather codes, with nulls as UnknownCode.
+func Codes(values []*int64) []int64 {
+	codes := make([]int64, len(values))
+	for i, v := range values {
+		codes[i] = UnknownCode
+		if v != nil {
+			codes[i] = *v
+		}
+	}
+	return codes
+}
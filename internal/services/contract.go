@@ -1,10 +1,15 @@
 package services
 
 import (
+	"context"
+
 	"meteo/config"
 	"meteo/internal/domain"
 )
 
+// Contract is implemented by weather providers, and by the services
+// wrapping them. The location is resolved beforehand; cfg holds the
+// forecast horizon and the credentials.
 type Contract interface {
-	Get(cfg *config.Config) (*domain.WeatherData, error)
+	Get(ctx context.Context, loc domain.Location, cfg *config.Config) (*domain.WeatherData, error)
 }
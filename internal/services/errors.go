@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Kinds of provider failures, to be tested with errors.Is.
+var (
+	ErrUnauthorized        = errors.New("unauthorized")
+	ErrRateLimited         = errors.New("rate limited")
+	ErrUpstreamUnavailable = errors.New("provider unavailable")
+	ErrInvalidResponse     = errors.New("invalid response")
+	ErrInvalidLocation     = errors.New("invalid location")
+)
+
+// ProviderError describes a failed request to a provider.
+type ProviderError struct {
+	Provider string
+
+	// Kind is one of the Err variables above, nil when the failure fits
+	// none of them.
+	Kind error
+
+	// StatusCode is the HTTP status of the response, 0 when there was
+	// none.
+	StatusCode int
+
+	// Message is the reason given by the provider, if any.
+	Message string
+
+	// Err is the underlying error, if any.
+	Err error
+}
+
+func (e *ProviderError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Provider)
+	if e.Kind != nil {
+		b.WriteString(": ")
+		b.WriteString(e.Kind.Error())
+	}
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&b, " (HTTP %d)", e.StatusCode)
+	}
+	if e.Message != "" {
+		b.WriteString(": ")
+		b.WriteString(e.Message)
+	}
+	if e.Err != nil {
+		b.WriteString(": ")
+		b.WriteString(e.Err.Error())
+	}
+	return b.String()
+}
+
+func (e *ProviderError) Unwrap() []error {
+	var errs []error
+	if e.Kind != nil {
+		errs = append(errs, e.Kind)
+	}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
+
+// StatusError classifies a response with a status other than 200. message
+// is the reason parsed from the response body, if any.
+func StatusError(provider string, status int, message string) *ProviderError {
+	var kind error
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		kind = ErrUnauthorized
+	case status == http.StatusTooManyRequests:
+		kind = ErrRateLimited
+	case status == http.StatusRequestTimeout || status >= 500:
+		kind = ErrUpstreamUnavailable
+	}
+	return &ProviderError{Provider: provider, Kind: kind, StatusCode: status, Message: message}
+}
+
+// RequestError classifies an error returned by the HTTP client for a
+// request made with ctx. The URL is dropped from the message since it may
+// contain credentials. Errors caused by ctx, an interruption or the overall
+// timeout, have no kind. A timeout of the client itself, which also matches
+// context.DeadlineExceeded, means the provider is unavailable.
+func RequestError(ctx context.Context, provider string, err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	if ctx.Err() != nil {
+		return &ProviderError{Provider: provider, Err: err}
+	}
+	return &ProviderError{Provider: provider, Kind: ErrUpstreamUnavailable, Err: err}
+}
+
+// ResponseError wraps an error found in the body of a successful response.
+func ResponseError(provider string, err error) error {
+	return &ProviderError{Provider: provider, Kind: ErrInvalidResponse, Err: err}
+}
+
+// WithProvider prefixes err with the provider name, unless it is a
+// *ProviderError which names the provider itself.
+func WithProvider(name string, err error) error {
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) {
+		return err
+	}
+	return fmt.Errorf("%s: %w", name, err)
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{status: 401, want: ErrUnauthorized},
+		{status: 403, want: ErrUnauthorized},
+		{status: 429, want: ErrRateLimited},
+		{status: 408, want: ErrUpstreamUnavailable},
+		{status: 500, want: ErrUpstreamUnavailable},
+		{status: 503, want: ErrUpstreamUnavailable},
+		{status: 400, want: nil},
+		{status: 404, want: nil},
+	}
+	kinds := []error{ErrUnauthorized, ErrRateLimited, ErrUpstreamUnavailable, ErrInvalidResponse, ErrInvalidLocation}
+	for _, tt := range tests {
+		err := StatusError("openmeteo", tt.status, "reason")
+		for _, kind := range kinds {
+			if got := errors.Is(err, kind); got != (kind == tt.want) {
+				t.Errorf("StatusError(%d) is %v = %v, want %v", tt.status, kind, got, !got)
+			}
+		}
+		if !strings.Contains(err.Error(), "reason") {
+			t.Errorf("StatusError(%d) = %q, want the reason in the message", tt.status, err)
+		}
+	}
+}
+
+func TestRequestError(t *testing.T) {
+	secret := &url.Error{Op: "Get", URL: "https://example.com/?apikey=secret", Err: errors.New("no such host")}
+	err := RequestError(context.Background(), "meteoblue", secret)
+	if !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Errorf("RequestError() = %v, want ErrUpstreamUnavailable", err)
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("RequestError() = %q, leaks the URL", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	canceled := &url.Error{Op: "Get", URL: "https://example.com/", Err: context.Canceled}
+	err = RequestError(ctx, "meteoblue", canceled)
+	if !errors.Is(err, context.Canceled) || errors.Is(err, ErrUpstreamUnavailable) {
+		t.Errorf("RequestError() = %v, want context.Canceled only", err)
+	}
+}
+
+func TestRequestError_clientTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := &http.Client{Timeout: 50 * time.Millisecond}
+	_, timeout := client.Get(server.URL)
+	var urlErr *url.Error
+	if !errors.As(timeout, &urlErr) || !errors.Is(timeout, context.DeadlineExceeded) {
+		t.Fatalf("client.Get() error = %v, want a *url.Error matching context.DeadlineExceeded", timeout)
+	}
+
+	err := RequestError(context.Background(), "openmeteo", timeout)
+	if !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Errorf("RequestError() = %v, want ErrUpstreamUnavailable", err)
+	}
+}
+
+func TestWithProvider(t *testing.T) {
+	err := WithProvider("openmeteo", StatusError("openmeteo", 500, ""))
+	if got := err.Error(); got != "openmeteo: provider unavailable (HTTP 500)" {
+		t.Errorf("WithProvider() = %q", got)
+	}
+	err = WithProvider("openmeteo", errors.New("boom"))
+	if got := err.Error(); got != "openmeteo: boom" {
+		t.Errorf("WithProvider() = %q", got)
+	}
+}
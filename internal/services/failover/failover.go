@@ -0,0 +1,61 @@
+package failover
+
+import (
+	"context"
+	"errors"
+
+	"meteo/config"
+	"meteo/internal/domain"
+	"meteo/internal/services"
+)
+
+// Provider is a named member of a failover chain.
+type Provider struct {
+	Name    string
+	Service services.Contract
+}
+
+type failover struct {
+	providers []Provider
+}
+
+// New returns a service asking the providers in order until one of them
+// answers. The name of that provider is recorded in the returned data. Only
+// failures of a provider pass the request on, others such as an invalid
+// location or too many forecast days are returned at once.
+func New(providers []Provider) services.Contract {
+	return &failover{
+		providers: providers,
+	}
+}
+
+func (f *failover) Get(ctx context.Context, loc domain.Location, cfg *config.Config) (*domain.WeatherData, error) {
+	var errs []error
+	for _, p := range f.providers {
+		data, err := p.Service.Get(ctx, loc, cfg)
+		if err == nil {
+			data.Provider = p.Name
+			return data, nil
+		}
+
+		errs = append(errs, services.WithProvider(p.Name, err))
+		if ctx.Err() != nil || !recoverable(err) {
+			// Interrupted, out of time or a request no provider can
+			// serve, the next provider would fail the same way.
+			break
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// recoverable reports whether another provider may answer after err: the
+// provider rejected the key or the quota, timed out, is down or answered
+// with a malformed forecast.
+func recoverable(err error) bool {
+	for _, kind := range []error{services.ErrUnauthorized, services.ErrRateLimited, services.ErrUpstreamUnavailable, services.ErrInvalidResponse, context.DeadlineExceeded} {
+		if errors.Is(err, kind) {
+			return true
+		}
+	}
+	return false
+}
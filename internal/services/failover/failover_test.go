@@ -0,0 +1,136 @@
+package failover
+
+import (
+	"context"
+	"errors"
+	"meteo/config"
+	"meteo/internal/domain"
+	"meteo/internal/services"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+type mockService struct {
+	data  *domain.WeatherData
+	err   error
+	calls int
+}
+
+func (m *mockService) Get(_ context.Context, _ domain.Location, _ *config.Config) (*domain.WeatherData, error) {
+	m.calls++
+	if m.data == nil {
+		return nil, m.err
+	}
+	data := *m.data
+	return &data, m.err
+}
+
+func Test_failover_Get(t *testing.T) {
+	weather := &domain.WeatherData{Time: []int64{1609459200}, Temperature: []float64{1.5}}
+
+	tests := []struct {
+		name         string
+		meteoblue    *mockService
+		openmeteo    *mockService
+		wantProvider string
+		wantErr      bool
+		wantCalls    []int
+	}{
+		{
+			name:         "First provider answers",
+			meteoblue:    &mockService{data: weather},
+			openmeteo:    &mockService{data: weather},
+			wantProvider: "meteoblue",
+			wantCalls:    []int{1, 0},
+		},
+		{
+			name:         "Second provider answers",
+			meteoblue:    &mockService{err: services.StatusError("meteoblue", http.StatusUnauthorized, "")},
+			openmeteo:    &mockService{data: weather},
+			wantProvider: "openmeteo",
+			wantCalls:    []int{1, 1},
+		},
+		{
+			name:         "Second provider answers after a timeout",
+			meteoblue:    &mockService{err: context.DeadlineExceeded},
+			openmeteo:    &mockService{data: weather},
+			wantProvider: "openmeteo",
+			wantCalls:    []int{1, 1},
+		},
+		{
+			name:         "Second provider answers after a malformed forecast",
+			meteoblue:    &mockService{err: services.ResponseError("meteoblue", domain.ErrMalformed)},
+			openmeteo:    &mockService{data: weather},
+			wantProvider: "openmeteo",
+			wantCalls:    []int{1, 1},
+		},
+		{
+			name:      "All providers fail",
+			meteoblue: &mockService{err: services.StatusError("meteoblue", http.StatusUnauthorized, "")},
+			openmeteo: &mockService{err: services.StatusError("openmeteo", http.StatusServiceUnavailable, "")},
+			wantErr:   true,
+			wantCalls: []int{1, 1},
+		},
+		{
+			name:      "Too many forecast days are not passed on",
+			meteoblue: &mockService{err: services.ValidateForecastDays(20, 14)},
+			openmeteo: &mockService{data: weather},
+			wantErr:   true,
+			wantCalls: []int{1, 0},
+		},
+		{
+			name:      "Invalid location is not passed on",
+			meteoblue: &mockService{err: services.ValidateCoordinates(91, 0)},
+			openmeteo: &mockService{data: weather},
+			wantErr:   true,
+			wantCalls: []int{1, 0},
+		},
+		{
+			name:      "Unclassified error is not passed on",
+			meteoblue: &mockService{err: errors.New("config error")},
+			openmeteo: &mockService{data: weather},
+			wantErr:   true,
+			wantCalls: []int{1, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := New([]Provider{
+				{Name: "meteoblue", Service: tt.meteoblue},
+				{Name: "openmeteo", Service: tt.openmeteo},
+			})
+
+			got, err := f.Get(context.Background(), domain.Location{}, &config.Config{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("failover.Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Provider != tt.wantProvider {
+				t.Errorf("failover.Get() provider = %v, want %v", got.Provider, tt.wantProvider)
+			}
+			calls := []int{tt.meteoblue.calls, tt.openmeteo.calls}
+			if !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("provider calls = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func Test_failover_Get_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	meteoblue := &mockService{err: context.Canceled}
+	openmeteo := &mockService{data: &domain.WeatherData{}}
+	f := New([]Provider{
+		{Name: "meteoblue", Service: meteoblue},
+		{Name: "openmeteo", Service: openmeteo},
+	})
+
+	if _, err := f.Get(ctx, domain.Location{}, &config.Config{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("failover.Get() error = %v, want %v", err, context.Canceled)
+	}
+	if openmeteo.calls != 0 {
+		t.Errorf("openmeteo calls = %d, want 0", openmeteo.calls)
+	}
+}
@@ -0,0 +1,47 @@
+package fanout
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"meteo/config"
+	"meteo/internal/domain"
+	"meteo/internal/services"
+)
+
+// Member is a named provider taking part in a concurrent fetch.
+type Member struct {
+	Name    string
+	Service services.Contract
+}
+
+// Fetch asks all members concurrently for the forecast at loc and returns
+// their forecasts in the order of members. It fails if any member fails, since a partial set of
+// forecasts would understate the disagreement between them.
+func Fetch(ctx context.Context, loc domain.Location, cfg *config.Config, members []Member) ([]*domain.WeatherData, error) {
+	results := make([]*domain.WeatherData, len(members))
+	errs := make([]error, len(members))
+
+	var wg sync.WaitGroup
+	for i, m := range members {
+		wg.Add(1)
+		go func(i int, m Member) {
+			defer wg.Done()
+
+			data, err := m.Service.Get(ctx, loc, cfg)
+			if err != nil {
+				errs[i] = services.WithProvider(m.Name, err)
+				return
+			}
+			data.Provider = m.Name
+			results[i] = data
+		}(i, m)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
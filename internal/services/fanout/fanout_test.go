@@ -0,0 +1,77 @@
+package fanout
+
+import (
+	"context"
+	"errors"
+	"meteo/config"
+	"meteo/internal/domain"
+	"sync"
+	"testing"
+)
+
+type mockService struct {
+	data    *domain.WeatherData
+	err     error
+	started *sync.WaitGroup
+}
+
+func (m *mockService) Get(_ context.Context, _ domain.Location, _ *config.Config) (*domain.WeatherData, error) {
+	// Block until every member has started, which only succeeds when the
+	// members are asked concurrently.
+	m.started.Done()
+	m.started.Wait()
+
+	if m.err != nil {
+		return nil, m.err
+	}
+	data := *m.data
+	return &data, nil
+}
+
+func TestFetch(t *testing.T) {
+	weather := &domain.WeatherData{Time: []int64{3600}}
+
+	tests := []struct {
+		name    string
+		errs    []error
+		wantErr bool
+	}{
+		{
+			name: "All members answer",
+			errs: []error{nil, nil},
+		},
+		{
+			name:    "One member fails",
+			errs:    []error{nil, errors.New("unexpected status code: 503")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			started := &sync.WaitGroup{}
+			started.Add(len(tt.errs))
+
+			names := []string{"openmeteo", "meteoblue"}
+			members := make([]Member, len(tt.errs))
+			for i, err := range tt.errs {
+				members[i] = Member{
+					Name:    names[i],
+					Service: &mockService{data: weather, err: err, started: started},
+				}
+			}
+
+			got, err := Fetch(context.Background(), domain.Location{}, &config.Config{}, members)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Fetch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			for i, data := range got {
+				if data.Provider != names[i] {
+					t.Errorf("Fetch()[%d].Provider = %v, want %v", i, data.Provider, names[i])
+				}
+			}
+		})
+	}
+}
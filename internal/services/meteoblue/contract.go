@@ -3,5 +3,5 @@ package meteoblue
 import "net/http"
 
 type httpClient interface {
-	Get(string) (*http.Response, error)
+	Do(*http.Request) (*http.Response, error)
 }
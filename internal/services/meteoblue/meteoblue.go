@@ -1,6 +1,7 @@
 package meteoblue
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
@@ -11,8 +12,29 @@ import (
 	"meteo/internal/domain"
 	"meteo/internal/dto"
 	"meteo/internal/services"
+	"net/http"
 )
 
+// providerName names the provider in errors.
+const providerName = "meteoblue"
+
+// MaxForecastDays is the longest horizon served by the basic-1h package.
+const MaxForecastDays = 14
+
+// Variables lists the variables of domain.WeatherData the basic-1h package
+// delivers.
+var Variables = []domain.Variable{
+	domain.Temperature,
+	domain.ApparentTemperature,
+	domain.PrecipitationProbability,
+	domain.Precipitation,
+	domain.Humidity,
+	domain.Pressure,
+	domain.WindSpeed,
+	domain.WindDirection,
+	domain.UVIndex,
+}
+
 var meteobluePictocodes = map[int64]string{
 	1:  "Clear, cloudless sky",
 	2:  "Clear, few cirrus",
@@ -61,55 +83,74 @@ func NewMeteoblue(client httpClient) services.Contract {
 	}
 }
 
-func (mb *meteoblue) fetchMeteoblueData(url string) (*domain.MeteoblueWeatherData, error) {
+func (mb *meteoblue) fetchMeteoblueData(ctx context.Context, url string) (*domain.MeteoblueWeatherData, error) {
 	weatherDto := dto.MeteoblueWeatherData{}
 
 	// Get data from meteoblue.
-	resp, err := mb.client.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	resp, err := mb.client.Do(req)
+	if err != nil {
+		return nil, services.RequestError(ctx, providerName, err)
 	}
+	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, services.RequestError(ctx, providerName, err)
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, statusError(resp.StatusCode, body)
 	}
 
 	err = json.Unmarshal(body, &weatherDto)
 	if err != nil {
-		return nil, err
+		return nil, services.ResponseError(providerName, err)
 	}
 
 	// Convert dto to domain.
+	times, err := services.Timestamps(weatherDto.MeteoblueData1h.Time)
+	if err != nil {
+		return nil, services.ResponseError(providerName, err)
+	}
 	data := &domain.MeteoblueWeatherData{
 		MeteoblueMetadata: domain.MeteoblueMetadata{
 			Latitude:  weatherDto.MeteoblueMetadata.Latitude,
 			Longitude: weatherDto.MeteoblueMetadata.Longitude,
 		},
 		MeteoblueData1h: domain.MeteoblueData1h{
-			Time:                     weatherDto.MeteoblueData1h.Time,
-			Temperature:              weatherDto.MeteoblueData1h.Temperature,
-			PrecipitationProbability: weatherDto.MeteoblueData1h.PrecipitationProbability,
-			Pictocode:                weatherDto.MeteoblueData1h.Pictocode,
-			WindSpeed:                weatherDto.MeteoblueData1h.WindSpeed,
+			Time:                     times,
+			Temperature:              domain.FromNullable(weatherDto.MeteoblueData1h.Temperature),
+			PrecipitationProbability: domain.FromNullable(weatherDto.MeteoblueData1h.PrecipitationProbability),
+			Pictocode:                services.Codes(weatherDto.MeteoblueData1h.Pictocode),
+			WindSpeed:                domain.FromNullable(weatherDto.MeteoblueData1h.WindSpeed),
+			FeltTemperature:          domain.FromNullable(weatherDto.MeteoblueData1h.FeltTemperature),
+			Precipitation:            domain.FromNullable(weatherDto.MeteoblueData1h.Precipitation),
+			RelativeHumidity:         domain.FromNullable(weatherDto.MeteoblueData1h.RelativeHumidity),
+			SeaLevelPressure:         domain.FromNullable(weatherDto.MeteoblueData1h.SeaLevelPressure),
+			WindDirection:            domain.FromNullable(weatherDto.MeteoblueData1h.WindDirection),
+			UVIndex:                  domain.FromNullable(weatherDto.MeteoblueData1h.UVIndex),
 		},
 	}
 
 	return data, nil
 }
 
-func (mb *meteoblue) Get(cfg *config.Config) (*domain.WeatherData, error) {
-	url, err := createURL(cfg.Latitude, cfg.Longitude, cfg.MeteoblueAPIKey, cfg.MeteoblueAPISharedSecret)
+func (mb *meteoblue) Get(ctx context.Context, loc domain.Location, cfg *config.Config) (*domain.WeatherData, error) {
+	// Credentials kept in a file or a secret store are only read when a
+	// forecast is actually fetched, not for cached ones.
+	if err := cfg.ResolveSecrets(); err != nil {
+		return nil, err
+	}
+	url, err := createURL(loc.Latitude, loc.Longitude, loc.Elevation, cfg.Days, cfg.MeteoblueAPIKey, cfg.MeteoblueAPISharedSecret)
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := mb.fetchMeteoblueData(url)
+	data, err := mb.fetchMeteoblueData(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -125,23 +166,53 @@ func (mb *meteoblue) Get(cfg *config.Config) (*domain.WeatherData, error) {
 
 		weatherState[i] = state
 	}
-	return &domain.WeatherData{
+	// The basic package has no dew point, cloud cover, gusts or visibility,
+	// and names the timezone only by its abbreviation, which is not used.
+	weather := &domain.WeatherData{
 		Time:                     data.MeteoblueData1h.Time,
 		Temperature:              data.MeteoblueData1h.Temperature,
 		PrecipitationProbability: data.MeteoblueData1h.PrecipitationProbability,
 		WeatherState:             weatherState,
 		WindSpeed:                data.MeteoblueData1h.WindSpeed,
-	}, nil
+		ApparentTemperature:      data.MeteoblueData1h.FeltTemperature,
+		Precipitation:            data.MeteoblueData1h.Precipitation,
+		Humidity:                 data.MeteoblueData1h.RelativeHumidity,
+		Pressure:                 data.MeteoblueData1h.SeaLevelPressure,
+		WindDirection:            data.MeteoblueData1h.WindDirection,
+		UVIndex:                  data.MeteoblueData1h.UVIndex,
+	}
+	if err := weather.Validate(); err != nil {
+		return nil, services.ResponseError(providerName, err)
+	}
+	return weather, nil
 }
 
-func createURL(lat, lng float64, apiKey, sharedSecret string) (string, error) {
+// statusError classifies an error response, whose body gives the reason.
+func statusError(status int, body []byte) error {
+	var reason dto.MeteoblueError
+	_ = json.Unmarshal(body, &reason)
+	return services.StatusError(providerName, status, reason.ErrorMessage)
+}
+
+// createURL builds the signed forecast request. Without an altitude the API
+// takes the elevation of the location from its own terrain model.
+func createURL(lat, lng float64, altitude *float64, days int, apiKey, sharedSecret string) (string, error) {
 	if err := services.ValidateCoordinates(lat, lng); err != nil {
 		return "", err
 	}
+	if err := services.ValidateForecastDays(days, MaxForecastDays); err != nil {
+		return "", err
+	}
 
+	// Values are requested in the metric units of domain.WeatherData and
+	// converted to the configured units by the caller.
+	position := fmt.Sprintf("lat=%.6f&lon=%.6f", lat, lng)
+	if altitude != nil {
+		position += fmt.Sprintf("&asl=%.0f", *altitude)
+	}
 	query := fmt.Sprintf(
-		"/packages/basic-1h?lat=%.6f&lon=%.6f&apikey=%s&expire=1924948800&forecast_days=3&temperature=C&timeformat=timestamp_utc",
-		lat, lng, apiKey,
+		"/packages/basic-1h?%s&apikey=%s&expire=1924948800&forecast_days=%d&temperature=C&windspeed=kmh&precipitationamount=mm&timeformat=timestamp_utc",
+		position, apiKey, days,
 	)
 
 	sig := generateSignature(query, sharedSecret)
@@ -2,13 +2,16 @@ package meteoblue
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"meteo/config"
 	"meteo/internal/domain"
+	"meteo/internal/services"
 	"meteo/internal/services/meteoblue/mocks"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -94,7 +97,7 @@ func Test_meteoblue_fetchMeteoblueData(t *testing.T) {
 			}
 
 			mb := meteoblue{client: client}
-			data, err := mb.fetchMeteoblueData(tt.url)
+			data, err := mb.fetchMeteoblueData(context.Background(), tt.url)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("get() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -107,6 +110,7 @@ func Test_meteoblue_fetchMeteoblueData(t *testing.T) {
 
 func Test_meteoblue_Get(t *testing.T) {
 	type args struct {
+		loc domain.Location
 		cfg *config.Config
 	}
 	tests := []struct {
@@ -120,9 +124,9 @@ func Test_meteoblue_Get(t *testing.T) {
 		{
 			name: "successful API call",
 			args: args{
+				loc: domain.Location{Latitude: 0, Longitude: 0},
 				cfg: &config.Config{
-					Latitude:                 0.0,
-					Longitude:                0.0,
+					Days:                     3,
 					MeteoblueAPIKey:          "meteoblue-api-key",
 					MeteoblueAPISharedSecret: "meteoblue-shared-secret",
 				},
@@ -137,7 +141,10 @@ func Test_meteoblue_Get(t *testing.T) {
 					"temperature": [1.1, 2.2],
 					"precipitation_probability": [0.0, 0.1],
 					"pictocode": [1, 7],
-					"windspeed": [3.3, 4.4]
+					"windspeed": [3.3, 4.4],
+					"felttemperature": [-1.2, 0.4],
+					"precipitation": [0.0, 0.3],
+					"winddirection": [270, 280]
 				}
 			}`,
 			mockStatus: http.StatusOK,
@@ -147,15 +154,41 @@ func Test_meteoblue_Get(t *testing.T) {
 				PrecipitationProbability: []float64{0.0, 0.1},
 				WeatherState:             []string{"Clear, cloudless sky", "Partly cloudy"},
 				WindSpeed:                []float64{3.3, 4.4},
+				ApparentTemperature:      []float64{-1.2, 0.4},
+				Precipitation:            []float64{0.0, 0.3},
+				WindDirection:            []float64{270, 280},
 			},
 			wantErr: false,
 		},
+		{
+			name: "Ragged response",
+			args: args{
+				loc: domain.Location{Latitude: 0, Longitude: 0},
+				cfg: &config.Config{
+					Days:                     3,
+					MeteoblueAPIKey:          "meteoblue-api-key",
+					MeteoblueAPISharedSecret: "meteoblue-shared-secret",
+				},
+			},
+			mockResponse: `{
+				"data_1h": {
+					"time": [1609459200, 1609462800],
+					"temperature": [1.1, 2.2],
+					"precipitation_probability": [0.0, 0.1],
+					"pictocode": [1],
+					"windspeed": [3.3, 4.4]
+				}
+			}`,
+			mockStatus: http.StatusOK,
+			want:       nil,
+			wantErr:    true,
+		},
 		{
 			name: "API call returns error",
 			args: args{
+				loc: domain.Location{Latitude: 0, Longitude: 0},
 				cfg: &config.Config{
-					Latitude:                 0.0,
-					Longitude:                0.0,
+					Days:                     3,
 					MeteoblueAPIKey:          "meteoblue-api-key",
 					MeteoblueAPISharedSecret: "meteoblue-shared-secret",
 				},
@@ -168,9 +201,9 @@ func Test_meteoblue_Get(t *testing.T) {
 		{
 			name: "Invalid latitude and longitude",
 			args: args{
+				loc: domain.Location{Latitude: 100, Longitude: 200}, // Invalid coordinates
 				cfg: &config.Config{
-					Latitude:                 100.0, // Invalid latitude
-					Longitude:                200.0, // Invalid longitude
+					Days:                     3,
 					MeteoblueAPIKey:          "meteoblue-api-key",
 					MeteoblueAPISharedSecret: "meteoblue-shared-secret",
 				},
@@ -194,7 +227,7 @@ func Test_meteoblue_Get(t *testing.T) {
 
 			mb := NewMeteoblue(mockHttpClient)
 
-			got, err := mb.Get(tt.args.cfg)
+			got, err := mb.Get(context.Background(), tt.args.loc, tt.args.cfg)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("meteoblue.Get() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -210,6 +243,8 @@ func Test_createURL(t *testing.T) {
 	type args struct {
 		lat          float64
 		lng          float64
+		altitude     *float64
+		days         int
 		apiKey       string
 		sharedSecret string
 	}
@@ -224,10 +259,24 @@ func Test_createURL(t *testing.T) {
 			args: args{
 				lat:          37.7749,
 				lng:          -122.4194,
+				days:         3,
+				apiKey:       "testApiKey",
+				sharedSecret: "testSecret",
+			},
+			want:    "https://my.meteoblue.com/packages/basic-1h?lat=37.774900&lon=-122.419400&apikey=testApiKey&expire=1924948800&forecast_days=3&temperature=C&windspeed=kmh&precipitationamount=mm&timeformat=timestamp_utc&sig=fb1ce75257e2c0fd2c089fa1e5df9f2b2cb003aae849ab44bb03e77439f44243",
+			wantErr: false,
+		},
+		{
+			name: "Altitude",
+			args: args{
+				lat:          46.02,
+				lng:          7.75,
+				altitude:     ptr(1608.4),
+				days:         3,
 				apiKey:       "testApiKey",
 				sharedSecret: "testSecret",
 			},
-			want:    "https://my.meteoblue.com/packages/basic-1h?lat=37.774900&lon=-122.419400&apikey=testApiKey&expire=1924948800&forecast_days=3&temperature=C&timeformat=timestamp_utc&sig=ce1763b9edd8fc1e68ec7af70b81bf9ec8b1679b795eb189d88ec270ed22716a",
+			want:    "https://my.meteoblue.com/packages/basic-1h?lat=46.020000&lon=7.750000&asl=1608&apikey=testApiKey&expire=1924948800&forecast_days=3&temperature=C&windspeed=kmh&precipitationamount=mm&timeformat=timestamp_utc&sig=4ac4c6cd2fe04c1b8695b1892d212a7c59f0676271075a7dadd59aa3f3a8db6e",
 			wantErr: false,
 		},
 		{
@@ -235,6 +284,19 @@ func Test_createURL(t *testing.T) {
 			args: args{
 				lat:          100.0, // Invalid latitude
 				lng:          200.0, // Invalid longitude
+				days:         3,
+				apiKey:       "testApiKey",
+				sharedSecret: "testSecret",
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "Too many forecast days",
+			args: args{
+				lat:          37.7749,
+				lng:          -122.4194,
+				days:         15,
 				apiKey:       "testApiKey",
 				sharedSecret: "testSecret",
 			},
@@ -246,16 +308,17 @@ func Test_createURL(t *testing.T) {
 			args: args{
 				lat:          37.7749,
 				lng:          -122.4194,
+				days:         3,
 				apiKey:       "",
 				sharedSecret: "",
 			},
-			want:    "https://my.meteoblue.com/packages/basic-1h?lat=37.774900&lon=-122.419400&apikey=&expire=1924948800&forecast_days=3&temperature=C&timeformat=timestamp_utc&sig=dcf9f0a021f16c291f89bb0f3c9e4e561900e967b3d30fb159179f603b806eb8",
+			want:    "https://my.meteoblue.com/packages/basic-1h?lat=37.774900&lon=-122.419400&apikey=&expire=1924948800&forecast_days=3&temperature=C&windspeed=kmh&precipitationamount=mm&timeformat=timestamp_utc&sig=eef9db2a0b016474338e756316cb2f6716928814b02f5541334db3bfd5a6a86c",
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := createURL(tt.args.lat, tt.args.lng, tt.args.apiKey, tt.args.sharedSecret)
+			got, err := createURL(tt.args.lat, tt.args.lng, tt.args.altitude, tt.args.days, tt.args.apiKey, tt.args.sharedSecret)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("createURL() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -294,3 +357,27 @@ func Test_generateSignature(t *testing.T) {
 		})
 	}
 }
+
+func Test_meteoblue_Get_unauthorized(t *testing.T) {
+	mockHttpClient := &mocks.MockClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Body:       io.NopCloser(bytes.NewReader([]byte(`{"error_message": "API key invalid"}`))),
+			}, nil
+		},
+	}
+
+	cfg := &config.Config{Days: 3, MeteoblueAPIKey: "key", MeteoblueAPISharedSecret: "secret"}
+	_, err := NewMeteoblue(mockHttpClient).Get(context.Background(), domain.Location{}, cfg)
+	if !errors.Is(err, services.ErrUnauthorized) {
+		t.Errorf("meteoblue.Get() error = %v, want ErrUnauthorized", err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "API key invalid") {
+		t.Errorf("meteoblue.Get() error = %q, want the reason given by meteoblue", err)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
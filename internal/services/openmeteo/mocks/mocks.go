@@ -6,7 +6,6 @@ type MockClient struct {
 	DoFunc func(req *http.Request) (*http.Response, error)
 }
 
-func (m *MockClient) Get(url string) (*http.Response, error) {
-	req, _ := http.NewRequest(http.MethodGet, url, nil)
+func (m *MockClient) Do(req *http.Request) (*http.Response, error) {
 	return m.DoFunc(req)
 }
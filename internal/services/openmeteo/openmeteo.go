@@ -1,9 +1,12 @@
 package openmeteo
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
+	"strings"
 
 	"meteo/config"
 	"meteo/internal/domain"
@@ -13,6 +16,22 @@ import (
 
 const baseURL = "https://api.open-meteo.com/v1/forecast?latitude=%f&longitude=%f"
 
+// Variables lists the variables of domain.WeatherData the forecast API
+// delivers, which are all of them.
+var Variables = domain.Variables
+
+// hourlyVariables are requested in the default units of the API, which are
+// the metric units of domain.WeatherData.
+const hourlyVariables = "temperature_2m,precipitation_probability,weathercode,windspeed_10m," +
+	"apparent_temperature,precipitation,relativehumidity_2m,dewpoint_2m,pressure_msl," +
+	"cloudcover,winddirection_10m,windgusts_10m,uv_index,visibility"
+
+// providerName names the provider in errors.
+const providerName = "openmeteo"
+
+// MaxForecastDays is the longest horizon served by the forecast API.
+const MaxForecastDays = 16
+
 var openmeteoWeatherCodes = map[int64]string{
 	0:  "Clear sky",
 	1:  "Mainly clear",
@@ -54,53 +73,72 @@ func NewOpenmeteo(client httpClient) services.Contract {
 	}
 }
 
-func (om *openmeteo) fetchOpenmeteoData(url string) (*domain.OpenmeteoWeatherData, error) {
+func (om *openmeteo) fetchOpenmeteoData(ctx context.Context, url string) (*domain.OpenmeteoWeatherData, error) {
 	weatherDto := dto.OpenmeteoWeatherData{}
 
 	// Get data from openmeteo.
-	resp, err := om.client.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	resp, err := om.client.Do(req)
+	if err != nil {
+		return nil, services.RequestError(ctx, providerName, err)
 	}
+	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, services.RequestError(ctx, providerName, err)
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, statusError(resp.StatusCode, body)
 	}
 
 	err = json.Unmarshal(body, &weatherDto)
 	if err != nil {
-		return nil, err
+		return nil, services.ResponseError(providerName, err)
 	}
 
 	// Convert dto to domain.
+	times, err := services.Timestamps(weatherDto.Hourly.Time)
+	if err != nil {
+		return nil, services.ResponseError(providerName, err)
+	}
 	data := &domain.OpenmeteoWeatherData{
 		Latitude:  weatherDto.Latitude,
 		Longitude: weatherDto.Longitude,
+		Timezone:  weatherDto.Timezone,
 		Hourly: domain.OpenmeteoHourlyData{
-			Time:                     weatherDto.Hourly.Time,
-			Temperature:              weatherDto.Hourly.Temperature,
-			PrecipitationProbability: weatherDto.Hourly.PrecipitationProbability,
-			WeatherCode:              weatherDto.Hourly.WeatherCode,
-			WindSpeed:                weatherDto.Hourly.WindSpeed,
+			Time:                     times,
+			Temperature:              domain.FromNullable(weatherDto.Hourly.Temperature),
+			PrecipitationProbability: domain.FromNullable(weatherDto.Hourly.PrecipitationProbability),
+			WeatherCode:              services.Codes(weatherDto.Hourly.WeatherCode),
+			WindSpeed:                domain.FromNullable(weatherDto.Hourly.WindSpeed),
+			ApparentTemperature:      domain.FromNullable(weatherDto.Hourly.ApparentTemperature),
+			Precipitation:            domain.FromNullable(weatherDto.Hourly.Precipitation),
+			Humidity:                 domain.FromNullable(weatherDto.Hourly.Humidity),
+			DewPoint:                 domain.FromNullable(weatherDto.Hourly.DewPoint),
+			Pressure:                 domain.FromNullable(weatherDto.Hourly.Pressure),
+			CloudCover:               domain.FromNullable(weatherDto.Hourly.CloudCover),
+			WindDirection:            domain.FromNullable(weatherDto.Hourly.WindDirection),
+			WindGusts:                domain.FromNullable(weatherDto.Hourly.WindGusts),
+			UVIndex:                  domain.FromNullable(weatherDto.Hourly.UVIndex),
+			Visibility:               domain.FromNullable(weatherDto.Hourly.Visibility),
 		},
 	}
 
 	return data, nil
 }
 
-func (om *openmeteo) Get(cfg *config.Config) (*domain.WeatherData, error) {
-	url, err := createURL(cfg.Latitude, cfg.Longitude)
+func (om *openmeteo) Get(ctx context.Context, loc domain.Location, cfg *config.Config) (*domain.WeatherData, error) {
+	url, err := createURL(loc.Latitude, loc.Longitude, loc.Elevation, cfg.Days)
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := om.fetchOpenmeteoData(url)
+	data, err := om.fetchOpenmeteoData(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -117,22 +155,60 @@ func (om *openmeteo) Get(cfg *config.Config) (*domain.WeatherData, error) {
 		weatherState[i] = state
 	}
 
-	return &domain.WeatherData{
+	weather := &domain.WeatherData{
 		Time:                     data.Hourly.Time,
 		Temperature:              data.Hourly.Temperature,
 		PrecipitationProbability: data.Hourly.PrecipitationProbability,
 		WeatherState:             weatherState,
 		WindSpeed:                data.Hourly.WindSpeed,
-	}, nil
+		ApparentTemperature:      data.Hourly.ApparentTemperature,
+		Precipitation:            data.Hourly.Precipitation,
+		Humidity:                 data.Hourly.Humidity,
+		DewPoint:                 data.Hourly.DewPoint,
+		Pressure:                 data.Hourly.Pressure,
+		CloudCover:               data.Hourly.CloudCover,
+		WindDirection:            data.Hourly.WindDirection,
+		WindGusts:                data.Hourly.WindGusts,
+		UVIndex:                  data.Hourly.UVIndex,
+		Visibility:               data.Hourly.Visibility,
+		Timezone:                 data.Timezone,
+	}
+	if err := weather.Validate(); err != nil {
+		return nil, services.ResponseError(providerName, err)
+	}
+	return weather, nil
+}
+
+// statusError classifies an error response, whose body gives the reason.
+// The API answers 400 to coordinates out of range.
+func statusError(status int, body []byte) error {
+	var reason dto.OpenmeteoError
+	_ = json.Unmarshal(body, &reason)
+
+	err := services.StatusError(providerName, status, reason.Reason)
+	lower := strings.ToLower(reason.Reason)
+	if status == http.StatusBadRequest && (strings.Contains(lower, "latitude") || strings.Contains(lower, "longitude")) {
+		err.Kind = services.ErrInvalidLocation
+	}
+	return err
 }
 
-func createURL(lat float64, lng float64) (string, error) {
+// createURL builds the forecast request. Without an altitude the API takes
+// the elevation of the grid cell from its own terrain model. The timezone of
+// the location is requested for display; the times stay in UTC.
+func createURL(lat float64, lng float64, altitude *float64, days int) (string, error) {
 	if err := services.ValidateCoordinates(lat, lng); err != nil {
 		return "", err
 	}
+	if err := services.ValidateForecastDays(days, MaxForecastDays); err != nil {
+		return "", err
+	}
 
 	url := fmt.Sprintf(baseURL, lat, lng)
-	url = url + "&hourly=temperature_2m,precipitation_probability,weathercode,windspeed_10m&forecast_days=3&timeformat=unixtime"
+	if altitude != nil {
+		url = url + fmt.Sprintf("&elevation=%g", *altitude)
+	}
+	url = url + fmt.Sprintf("&hourly=%s&forecast_days=%d&timeformat=unixtime&timezone=auto", hourlyVariables, days)
 
 	return url, nil
 }
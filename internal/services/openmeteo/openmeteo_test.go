@@ -2,10 +2,12 @@ package openmeteo
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"meteo/config"
 	"meteo/internal/domain"
+	"meteo/internal/services"
 	"meteo/internal/services/openmeteo/mocks"
 	"net/http"
 	"reflect"
@@ -92,7 +94,7 @@ func Test_openmeteo_fetchOpenmeteoData(t *testing.T) {
 			}
 
 			giver := openmeteo{client: client}
-			data, err := giver.fetchOpenmeteoData(tt.url)
+			data, err := giver.fetchOpenmeteoData(context.Background(), tt.url)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("get() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -105,6 +107,7 @@ func Test_openmeteo_fetchOpenmeteoData(t *testing.T) {
 
 func Test_openmeteo_Get(t *testing.T) {
 	type args struct {
+		loc domain.Location
 		cfg *config.Config
 	}
 	tests := []struct {
@@ -118,20 +121,24 @@ func Test_openmeteo_Get(t *testing.T) {
 		{
 			name: "successful API call",
 			args: args{
+				loc: domain.Location{Latitude: 0, Longitude: 0},
 				cfg: &config.Config{
-					Latitude:  0.0,
-					Longitude: 0.0,
+					Days: 3,
 				},
 			},
 			mockResponse: `{
 				"latitude": 0.0,
 				"longitude": 0.0,
+				"timezone": "GMT",
 				"hourly": {
 					"time": [1609459200, 1609462800],
 					"temperature_2m": [1.1, 2.2],
 					"precipitation_probability": [0.0, 0.1],
 					"weathercode": [0, 1],
-					"windspeed_10m": [3.3, 4.4]
+					"windspeed_10m": [3.3, 4.4],
+					"apparent_temperature": [-0.5, 0.7],
+					"relativehumidity_2m": [81, 78],
+					"uv_index": [0.0, 0.2]
 				}
 			}`,
 			mockStatus: http.StatusOK,
@@ -141,15 +148,61 @@ func Test_openmeteo_Get(t *testing.T) {
 				PrecipitationProbability: []float64{0.0, 0.1},
 				WeatherState:             []string{"Clear sky", "Mainly clear"},
 				WindSpeed:                []float64{3.3, 4.4},
+				ApparentTemperature:      []float64{-0.5, 0.7},
+				Humidity:                 []float64{81, 78},
+				UVIndex:                  []float64{0.0, 0.2},
+				Timezone:                 "GMT",
 			},
 			wantErr: false,
 		},
+		{
+			name: "Ragged response",
+			args: args{
+				loc: domain.Location{Latitude: 0, Longitude: 0},
+				cfg: &config.Config{
+					Days: 3,
+				},
+			},
+			mockResponse: `{
+				"hourly": {
+					"time": [1609459200, 1609462800],
+					"temperature_2m": [1.1],
+					"precipitation_probability": [0.0, 0.1],
+					"weathercode": [0, 1],
+					"windspeed_10m": [3.3, 4.4]
+				}
+			}`,
+			mockStatus: http.StatusOK,
+			want:       nil,
+			wantErr:    true,
+		},
+		{
+			name: "Null time",
+			args: args{
+				loc: domain.Location{Latitude: 0, Longitude: 0},
+				cfg: &config.Config{
+					Days: 3,
+				},
+			},
+			mockResponse: `{
+				"hourly": {
+					"time": [1609459200, null],
+					"temperature_2m": [1.1, 2.2],
+					"precipitation_probability": [0.0, 0.1],
+					"weathercode": [0, 1],
+					"windspeed_10m": [3.3, 4.4]
+				}
+			}`,
+			mockStatus: http.StatusOK,
+			want:       nil,
+			wantErr:    true,
+		},
 		{
 			name: "API call returns error",
 			args: args{
+				loc: domain.Location{Latitude: 0, Longitude: 0},
 				cfg: &config.Config{
-					Latitude:  0.0,
-					Longitude: 0.0,
+					Days: 3,
 				},
 			},
 			mockResponse: `{"error": "invalid request"}`,
@@ -160,9 +213,9 @@ func Test_openmeteo_Get(t *testing.T) {
 		{
 			name: "Invalid latitude and longitude",
 			args: args{
+				loc: domain.Location{Latitude: 100, Longitude: 200}, // Invalid coordinates
 				cfg: &config.Config{
-					Latitude:  100.0, // Invalid latitude
-					Longitude: 200.0, // Invalid longitude
+					Days: 3,
 				},
 			},
 			mockResponse: "",
@@ -184,7 +237,7 @@ func Test_openmeteo_Get(t *testing.T) {
 
 			om := NewOpenmeteo(mockHttpClient)
 
-			got, err := om.Get(tt.args.cfg)
+			got, err := om.Get(context.Background(), tt.args.loc, tt.args.cfg)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("openmeteo.Get() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -198,8 +251,10 @@ func Test_openmeteo_Get(t *testing.T) {
 
 func Test_createURL(t *testing.T) {
 	type args struct {
-		lat float64
-		lng float64
+		lat      float64
+		lng      float64
+		altitude *float64
+		days     int
 	}
 	tests := []struct {
 		name    string
@@ -209,36 +264,58 @@ func Test_createURL(t *testing.T) {
 	}{
 		{
 			name:    "Null Island",
-			args:    args{lat: 0.0, lng: 0.0},
-			want:    "https://api.open-meteo.com/v1/forecast?latitude=0.000000&longitude=0.000000&hourly=temperature_2m,precipitation_probability,weathercode,windspeed_10m&forecast_days=3&timeformat=unixtime",
+			args:    args{lat: 0.0, lng: 0.0, days: 3},
+			want:    "https://api.open-meteo.com/v1/forecast?latitude=0.000000&longitude=0.000000&hourly=temperature_2m,precipitation_probability,weathercode,windspeed_10m,apparent_temperature,precipitation,relativehumidity_2m,dewpoint_2m,pressure_msl,cloudcover,winddirection_10m,windgusts_10m,uv_index,visibility&forecast_days=3&timeformat=unixtime&timezone=auto",
 			wantErr: false,
 		},
 		{
 			name:    "Negative coordinates",
-			args:    args{lat: -45.0, lng: -90.0},
-			want:    "https://api.open-meteo.com/v1/forecast?latitude=-45.000000&longitude=-90.000000&hourly=temperature_2m,precipitation_probability,weathercode,windspeed_10m&forecast_days=3&timeformat=unixtime",
+			args:    args{lat: -45.0, lng: -90.0, days: 3},
+			want:    "https://api.open-meteo.com/v1/forecast?latitude=-45.000000&longitude=-90.000000&hourly=temperature_2m,precipitation_probability,weathercode,windspeed_10m,apparent_temperature,precipitation,relativehumidity_2m,dewpoint_2m,pressure_msl,cloudcover,winddirection_10m,windgusts_10m,uv_index,visibility&forecast_days=3&timeformat=unixtime&timezone=auto",
 			wantErr: false,
 		},
 		{
 			name:    "Floating point precision",
-			args:    args{lat: 37.7749, lng: -122.4194},
-			want:    "https://api.open-meteo.com/v1/forecast?latitude=37.774900&longitude=-122.419400&hourly=temperature_2m,precipitation_probability,weathercode,windspeed_10m&forecast_days=3&timeformat=unixtime",
+			args:    args{lat: 37.7749, lng: -122.4194, days: 3},
+			want:    "https://api.open-meteo.com/v1/forecast?latitude=37.774900&longitude=-122.419400&hourly=temperature_2m,precipitation_probability,weathercode,windspeed_10m,apparent_temperature,precipitation,relativehumidity_2m,dewpoint_2m,pressure_msl,cloudcover,winddirection_10m,windgusts_10m,uv_index,visibility&forecast_days=3&timeformat=unixtime&timezone=auto",
 			wantErr: false,
 		},
+		{
+			name:    "Altitude",
+			args:    args{lat: 46.02, lng: 7.75, altitude: ptr(1608.0), days: 3},
+			want:    "https://api.open-meteo.com/v1/forecast?latitude=46.020000&longitude=7.750000&elevation=1608&hourly=temperature_2m,precipitation_probability,weathercode,windspeed_10m,apparent_temperature,precipitation,relativehumidity_2m,dewpoint_2m,pressure_msl,cloudcover,winddirection_10m,windgusts_10m,uv_index,visibility&forecast_days=3&timeformat=unixtime&timezone=auto",
+			wantErr: false,
+		},
+		{
+			name:    "Longest forecast",
+			args:    args{lat: 0.0, lng: 0.0, days: 16},
+			want:    "https://api.open-meteo.com/v1/forecast?latitude=0.000000&longitude=0.000000&hourly=temperature_2m,precipitation_probability,weathercode,windspeed_10m,apparent_temperature,precipitation,relativehumidity_2m,dewpoint_2m,pressure_msl,cloudcover,winddirection_10m,windgusts_10m,uv_index,visibility&forecast_days=16&timeformat=unixtime&timezone=auto",
+			wantErr: false,
+		},
+		{
+			name:    "Too many forecast days",
+			args:    args{lat: 0.0, lng: 0.0, days: 17},
+			wantErr: true,
+		},
+		{
+			name:    "No forecast days",
+			args:    args{lat: 0.0, lng: 0.0, days: 0},
+			wantErr: true,
+		},
 		{
 			name:    "Incorrect latitude",
-			args:    args{lat: -95.0, lng: 0.0},
+			args:    args{lat: -95.0, lng: 0.0, days: 3},
 			wantErr: true,
 		},
 		{
 			name:    "Incorrect longitude",
-			args:    args{lat: 0.0, lng: -185},
+			args:    args{lat: 0.0, lng: -185, days: 3},
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := createURL(tt.args.lat, tt.args.lng)
+			got, err := createURL(tt.args.lat, tt.args.lng, tt.args.altitude, tt.args.days)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("get() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -248,3 +325,94 @@ func Test_createURL(t *testing.T) {
 		})
 	}
 }
+
+func Test_openmeteo_Get_nulls(t *testing.T) {
+	mockHttpClient := &mocks.MockClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: io.NopCloser(bytes.NewReader([]byte(`{
+					"hourly": {
+						"time": [1609459200, 1609462800],
+						"temperature_2m": [1.1, null],
+						"precipitation_probability": [null, 0.1],
+						"weathercode": [null, 1],
+						"windspeed_10m": [3.3, 4.4]
+					}
+				}`))),
+			}, nil
+		},
+	}
+
+	got, err := NewOpenmeteo(mockHttpClient).Get(context.Background(), domain.Location{}, &config.Config{Days: 3})
+	if err != nil {
+		t.Fatalf("openmeteo.Get() error = %v", err)
+	}
+	if got.Temperature[0] != 1.1 || !domain.IsMissing(got.Temperature[1]) {
+		t.Errorf("openmeteo.Get() temperature = %v, want [1.1 missing]", got.Temperature)
+	}
+	if !domain.IsMissing(got.PrecipitationProbability[0]) {
+		t.Errorf("openmeteo.Get() precipitation probability = %v, want a gap first", got.PrecipitationProbability)
+	}
+	if got.WeatherState[0] != "" || got.WeatherState[1] != "Mainly clear" {
+		t.Errorf("openmeteo.Get() weather state = %q, want [\"\" \"Mainly clear\"]", got.WeatherState)
+	}
+	if got.Humidity != nil {
+		t.Errorf("openmeteo.Get() humidity = %v, want nil for an absent variable", got.Humidity)
+	}
+}
+
+func Test_openmeteo_Get_errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockResponse string
+		mockStatus   int
+		want         error
+	}{
+		{
+			name:         "Coordinates out of range",
+			mockResponse: `{"error": true, "reason": "Latitude must be in range of -90 to 90°. Given: 91.0."}`,
+			mockStatus:   http.StatusBadRequest,
+			want:         services.ErrInvalidLocation,
+		},
+		{
+			name:         "Rate limited",
+			mockResponse: `{"error": true, "reason": "Daily API request limit exceeded."}`,
+			mockStatus:   http.StatusTooManyRequests,
+			want:         services.ErrRateLimited,
+		},
+		{
+			name:         "Outage",
+			mockResponse: `<html>Bad Gateway</html>`,
+			mockStatus:   http.StatusBadGateway,
+			want:         services.ErrUpstreamUnavailable,
+		},
+		{
+			name:         "Undecodable body",
+			mockResponse: `{"hourly": `,
+			mockStatus:   http.StatusOK,
+			want:         services.ErrInvalidResponse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHttpClient := &mocks.MockClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: tt.mockStatus,
+						Body:       io.NopCloser(bytes.NewReader([]byte(tt.mockResponse))),
+					}, nil
+				},
+			}
+
+			_, err := NewOpenmeteo(mockHttpClient).Get(context.Background(), domain.Location{}, &config.Config{Days: 3})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("openmeteo.Get() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
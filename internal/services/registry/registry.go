@@ -0,0 +1,91 @@
+package registry
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"meteo/internal/domain"
+	"meteo/internal/services"
+	"meteo/internal/services/meteoblue"
+	"meteo/internal/services/openmeteo"
+)
+
+type httpClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+type factory func(client httpClient) services.Contract
+
+type provider struct {
+	new       factory
+	variables []domain.Variable
+	maxDays   int
+}
+
+// providers maps a provider name, as used in the config file and on the
+// command line, to the constructor of its service, the variables it
+// delivers and the longest forecast it serves.
+var providers = map[string]provider{
+	"openmeteo": {
+		new: func(client httpClient) services.Contract {
+			return openmeteo.NewOpenmeteo(client)
+		},
+		variables: openmeteo.Variables,
+		maxDays:   openmeteo.MaxForecastDays,
+	},
+	"meteoblue": {
+		new: func(client httpClient) services.Contract {
+			return meteoblue.NewMeteoblue(client)
+		},
+		variables: meteoblue.Variables,
+		maxDays:   meteoblue.MaxForecastDays,
+	},
+}
+
+func lookup(name string) (provider, error) {
+	p, ok := providers[name]
+	if !ok {
+		return provider{}, fmt.Errorf("unknown provider %q, available: %s", name, strings.Join(Names(), ", "))
+	}
+	return p, nil
+}
+
+// New returns the weather service registered under the given name.
+func New(name string, client httpClient) (services.Contract, error) {
+	p, err := lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return p.new(client), nil
+}
+
+// Variables returns the variables delivered by the named provider.
+func Variables(name string) ([]domain.Variable, error) {
+	p, err := lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return p.variables, nil
+}
+
+// MaxForecastDays returns the number of forecast days served by the named
+// provider.
+func MaxForecastDays(name string) (int, error) {
+	p, err := lookup(name)
+	if err != nil {
+		return 0, err
+	}
+	return p.maxDays, nil
+}
+
+// Names returns the sorted names of all registered providers.
+func Names() []string {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,63 @@
+package registry
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestNames(t *testing.T) {
+	want := []string{"meteoblue", "openmeteo"}
+	if got := Names(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr string
+	}{
+		{name: "openmeteo"},
+		{name: "meteoblue"},
+		{name: "nowhere", wantErr: `unknown provider "nowhere", available: meteoblue, openmeteo`},
+		{name: "OpenMeteo", wantErr: `unknown provider "OpenMeteo", available: meteoblue, openmeteo`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, err := New(tt.name, http.DefaultClient)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("New() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || service == nil {
+				t.Errorf("New() = %v, %v", service, err)
+			}
+		})
+	}
+}
+
+func TestMaxForecastDays(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    int
+		wantErr bool
+	}{
+		{name: "openmeteo", want: 16},
+		{name: "meteoblue", want: 14},
+		{name: "nowhere", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MaxForecastDays(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MaxForecastDays() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("MaxForecastDays() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
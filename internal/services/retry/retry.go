@@ -0,0 +1,152 @@
+package retry
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	baseDelay = 500 * time.Millisecond
+	maxDelay  = 10 * time.Second
+
+	// maxRetryAfter is the longest Retry-After wait that is honoured.
+	// Responses asking for more are returned to the caller as they are.
+	maxRetryAfter = time.Minute
+)
+
+type httpClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Client retries requests that failed with a connection error, 429 Too Many
+// Requests or a 5xx status, waiting with jittered exponential backoff or as
+// long as the server asks for in Retry-After. Other 4xx responses are
+// permanent and returned immediately.
+type Client struct {
+	client  httpClient
+	retries int
+
+	// sleep and jitter are replaced in tests.
+	sleep  func(ctx context.Context, d time.Duration) error
+	jitter func(d time.Duration) time.Duration
+}
+
+func NewClient(client httpClient, retries int) *Client {
+	return &Client{
+		client:  client,
+		retries: retries,
+		sleep:   sleep,
+		jitter:  jitter,
+	}
+}
+
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.client.Do(req)
+
+		if attempt >= c.retries || !retryable(req, resp, err) {
+			return resp, err
+		}
+
+		delay := c.jitter(backoff(attempt))
+		if resp != nil {
+			if d, ok := retryAfter(resp); ok {
+				if d > maxRetryAfter {
+					return resp, nil
+				}
+				delay = d
+			}
+			// Drain the body so that the connection can be reused.
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := c.sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+		if req, err = rewind(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Body != nil && req.GetBody == nil {
+		// The body is consumed and cannot be sent again.
+		return false
+	}
+	if err != nil {
+		// Only the context of the request tells an interruption or the
+		// overall timeout apart. The errors of http.Client.Timeout also
+		// match context.DeadlineExceeded, but a hung provider is worth
+		// another attempt.
+		return req.Context().Err() == nil
+	}
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode == http.StatusRequestTimeout:
+		return true
+	case resp.StatusCode == http.StatusNotImplemented:
+		return false
+	default:
+		return resp.StatusCode >= 500
+	}
+}
+
+func rewind(req *http.Request) (*http.Request, error) {
+	if req.Body == nil {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Body = body
+	return req, nil
+}
+
+func backoff(attempt int) time.Duration {
+	d := baseDelay << attempt
+	if d <= 0 || d > maxDelay {
+		return maxDelay
+	}
+	return d
+}
+
+// retryAfter parses the Retry-After header given either in seconds or as
+// an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+// jitter returns a random duration between d/2 and d.
+func jitter(d time.Duration) time.Duration {
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
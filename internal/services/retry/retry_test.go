@@ -0,0 +1,200 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type mockResponse struct {
+	status     int
+	retryAfter string
+	err        error
+}
+
+type mockClient struct {
+	responses []mockResponse
+	calls     int
+}
+
+func (m *mockClient) Do(req *http.Request) (*http.Response, error) {
+	r := m.responses[min(m.calls, len(m.responses)-1)]
+	m.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	resp := &http.Response{
+		StatusCode: r.status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader("")),
+	}
+	if r.retryAfter != "" {
+		resp.Header.Set("Retry-After", r.retryAfter)
+	}
+	return resp, nil
+}
+
+func TestClient_Do(t *testing.T) {
+	tests := []struct {
+		name       string
+		responses  []mockResponse
+		retries    int
+		wantStatus int
+		wantErr    bool
+		wantCalls  int
+		wantDelays []time.Duration
+	}{
+		{
+			name:       "Success on first attempt",
+			responses:  []mockResponse{{status: 200}},
+			retries:    3,
+			wantStatus: 200,
+			wantCalls:  1,
+		},
+		{
+			name:       "Server errors are retried",
+			responses:  []mockResponse{{status: 503}, {status: 502}, {status: 200}},
+			retries:    3,
+			wantStatus: 200,
+			wantCalls:  3,
+			wantDelays: []time.Duration{500 * time.Millisecond, time.Second},
+		},
+		{
+			name:       "Connection errors are retried",
+			responses:  []mockResponse{{err: errors.New("connection reset")}, {status: 200}},
+			retries:    3,
+			wantStatus: 200,
+			wantCalls:  2,
+			wantDelays: []time.Duration{500 * time.Millisecond},
+		},
+		{
+			name:       "Retry-After is honoured",
+			responses:  []mockResponse{{status: 429, retryAfter: "7"}, {status: 200}},
+			retries:    3,
+			wantStatus: 200,
+			wantCalls:  2,
+			wantDelays: []time.Duration{7 * time.Second},
+		},
+		{
+			name:       "Too long Retry-After gives up",
+			responses:  []mockResponse{{status: 429, retryAfter: "3600"}},
+			retries:    3,
+			wantStatus: 429,
+			wantCalls:  1,
+		},
+		{
+			name:       "Client errors are permanent",
+			responses:  []mockResponse{{status: 401}},
+			retries:    3,
+			wantStatus: 401,
+			wantCalls:  1,
+		},
+		{
+			name:       "Last response after retries are exhausted",
+			responses:  []mockResponse{{status: 500}},
+			retries:    2,
+			wantStatus: 500,
+			wantCalls:  3,
+			wantDelays: []time.Duration{500 * time.Millisecond, time.Second},
+		},
+		{
+			name:      "Last error after retries are exhausted",
+			responses: []mockResponse{{err: errors.New("connection refused")}},
+			retries:   1,
+			wantErr:   true,
+			wantCalls: 2,
+			wantDelays: []time.Duration{
+				500 * time.Millisecond,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockClient{responses: tt.responses}
+			var delays []time.Duration
+
+			c := NewClient(mock, tt.retries)
+			c.jitter = func(d time.Duration) time.Duration { return d }
+			c.sleep = func(_ context.Context, d time.Duration) error {
+				delays = append(delays, d)
+				return nil
+			}
+
+			req, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+			resp, err := c.Do(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && resp.StatusCode != tt.wantStatus {
+				t.Errorf("Do() status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if mock.calls != tt.wantCalls {
+				t.Errorf("Do() calls = %d, want %d", mock.calls, tt.wantCalls)
+			}
+			if len(delays) != len(tt.wantDelays) {
+				t.Fatalf("Do() delays = %v, want %v", delays, tt.wantDelays)
+			}
+			for i := range delays {
+				if delays[i] != tt.wantDelays[i] {
+					t.Errorf("Do() delays = %v, want %v", delays, tt.wantDelays)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_Do_clientTimeout(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			// Hang until the client gives up.
+			<-r.Context().Done()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := NewClient(&http.Client{Timeout: 50 * time.Millisecond}, 1)
+	c.sleep = func(context.Context, time.Duration) error { return nil }
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v, want the request retried", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls != 2 {
+		t.Errorf("Do() = %d after %d calls, want 200 after 2", resp.StatusCode, calls)
+	}
+}
+
+func TestClient_Do_canceled(t *testing.T) {
+	mock := &mockClient{responses: []mockResponse{{status: 503}}}
+	c := NewClient(mock, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com", nil)
+	if _, err := c.Do(req); !errors.Is(err, context.Canceled) {
+		t.Errorf("Do() error = %v, want %v", err, context.Canceled)
+	}
+	if mock.calls != 1 {
+		t.Errorf("Do() calls = %d, want 1", mock.calls)
+	}
+}
+
+func Test_jitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if d := jitter(time.Second); d < 500*time.Millisecond || d > time.Second {
+			t.Fatalf("jitter() = %v, want between 500ms and 1s", d)
+		}
+	}
+}